@@ -0,0 +1,285 @@
+package codecs
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// DefaultAnyTypeKey is default name of BSON document key keeping Any type URL
+	DefaultAnyTypeKey = "@type"
+
+	// anyValueKey is name of BSON document key keeping Any message which is not stored as BSON document
+	anyValueKey = "value"
+
+	// Names of BSON document keys of Any stored in binary form
+	anyBinaryTypeURLKey = "typeurl"
+	anyBinaryValueKey   = "value"
+)
+
+var (
+	// Protobuf Any type
+	anyType = reflect.TypeOf(anypb.Any{})
+)
+
+// anyCodec is codec for Protobuf Any.
+// If type URL is resolved by proto type registry Any is stored as BSON embedded document
+// of packed message with type URL added under typeKey, e.g.:
+//
+//	{"@type": "type.googleapis.com/test.Data", "boolValue": true, ...}
+//
+// Messages which are not stored as BSON document by their codecs (wrappers, Timestamp, etc.)
+// are kept under "value" key the same way as JSON mapping does, e.g.:
+//
+//	{"@type": "type.googleapis.com/google.protobuf.Duration", "value": 1000000000}
+//
+// Any having unknown type URL is stored in binary form:
+//
+//	{"typeurl": "type.googleapis.com/unknown.Message", "value": BinData(0, "...")}
+//
+// Document form of unknown type is kept as raw BSON document with UnknownAnyKeep policy.
+type anyCodec struct {
+	typeKey       string
+	unknownPolicy UnknownAnyPolicy
+}
+
+// EncodeValue encodes Protobuf Any value to BSON value
+func (e *anyCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	v := messageOf(val).(*anypb.Any)
+
+	t := anyMessageType(v.GetTypeUrl())
+	if t == nil {
+		if e.isKeptDocument(v) {
+			return bsonrw.NewCopier().CopyDocumentFromBytes(vw, v.GetValue())
+		}
+		return e.encodeBinary(vw, v)
+	}
+	msg := reflect.New(t.Elem())
+	if err := proto.Unmarshal(v.GetValue(), protoadapt.MessageV2Of(msg.Interface().(protoadapt.MessageV1))); err != nil {
+		return err
+	}
+	docForm, err := isDocumentEncoded(ectx.Registry, t.Elem())
+	if err != nil {
+		return err
+	}
+	enc, err := ectx.LookupEncoder(t)
+	if err != nil {
+		return err
+	}
+
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	tvw, err := dw.WriteDocumentElement(e.typeKey)
+	if err != nil {
+		return err
+	}
+	if err = tvw.WriteString(v.GetTypeUrl()); err != nil {
+		return err
+	}
+
+	if !docForm {
+		mvw, err := dw.WriteDocumentElement(anyValueKey)
+		if err != nil {
+			return err
+		}
+		if err = enc.EncodeValue(ectx, mvw, msg); err != nil {
+			return err
+		}
+		return dw.WriteDocumentEnd()
+	}
+
+	// Encode message to separate buffer to merge its fields with type URL
+	var buf bsonrw.SliceWriter
+	mvw, err := bsonrw.NewBSONValueWriter(&buf)
+	if err != nil {
+		return err
+	}
+	if err = enc.EncodeValue(ectx, mvw, msg); err != nil {
+		return err
+	}
+	elems, err := bsoncore.Document(buf).Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		if elem.Key() == e.typeKey {
+			return fmt.Errorf("message %s has field conflicting with Any type key %q", t.Elem(), e.typeKey)
+		}
+		evw, err := dw.WriteDocumentElement(elem.Key())
+		if err != nil {
+			return err
+		}
+		ev := elem.Value()
+		if err = bsonrw.NewCopier().CopyValueFromBytes(evw, ev.Type, ev.Data); err != nil {
+			return err
+		}
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// encodeBinary encodes Any having unknown type URL as BSON document with type URL and raw bytes
+func (e *anyCodec) encodeBinary(vw bsonrw.ValueWriter, v *anypb.Any) error {
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	tvw, err := dw.WriteDocumentElement(anyBinaryTypeURLKey)
+	if err != nil {
+		return err
+	}
+	if err = tvw.WriteString(v.GetTypeUrl()); err != nil {
+		return err
+	}
+	bvw, err := dw.WriteDocumentElement(anyBinaryValueKey)
+	if err != nil {
+		return err
+	}
+	if err = bvw.WriteBinary(v.GetValue()); err != nil {
+		return err
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// DecodeValue decodes BSON value to Any value
+func (e *anyCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	switch vr.Type() {
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	default:
+		return fmt.Errorf("cannot decode %v into an Any", vr.Type())
+	}
+	doc, err := bsonrw.NewCopier().CopyDocumentToBytes(vr)
+	if err != nil {
+		return err
+	}
+
+	tv, err := bsoncore.Document(doc).LookupErr(e.typeKey)
+	if err != nil {
+		return e.decodeBinary(doc, val)
+	}
+	typeURL, ok := tv.StringValueOK()
+	if !ok {
+		return fmt.Errorf("type key %q of Any must be string, but got %v", e.typeKey, tv.Type)
+	}
+	t := anyMessageType(typeURL)
+	if t == nil {
+		if e.unknownPolicy == UnknownAnyKeep {
+			setMessage(val, &anypb.Any{TypeUrl: typeURL, Value: doc})
+			return nil
+		}
+		return fmt.Errorf("type URL %q of Any is not found in proto type registry", typeURL)
+	}
+	dec, err := ectx.LookupDecoder(t.Elem())
+	if err != nil {
+		return err
+	}
+
+	msg := reflect.New(t.Elem())
+	if !isStructCodec(dec) {
+		mv, err := bsoncore.Document(doc).LookupErr(anyValueKey)
+		if err != nil {
+			return fmt.Errorf("document of Any with type URL %q has no %q key", typeURL, anyValueKey)
+		}
+		err = dec.DecodeValue(ectx, bsonrw.NewBSONValueReader(mv.Type, mv.Data), msg.Elem())
+		if err != nil {
+			return err
+		}
+	} else {
+		// Drop type URL from document to decode message fields only
+		elems, err := bsoncore.Document(doc).Elements()
+		if err != nil {
+			return err
+		}
+		fields := make([][]byte, 0, len(elems))
+		for _, elem := range elems {
+			if elem.Key() != e.typeKey {
+				fields = append(fields, elem)
+			}
+		}
+		mdoc := bsoncore.BuildDocumentFromElements(nil, fields...)
+		if err = dec.DecodeValue(ectx, bsonrw.NewBSONDocumentReader(mdoc), msg.Elem()); err != nil {
+			return err
+		}
+	}
+
+	b, err := proto.Marshal(protoadapt.MessageV2Of(msg.Interface().(protoadapt.MessageV1)))
+	if err != nil {
+		return err
+	}
+	setMessage(val, &anypb.Any{TypeUrl: typeURL, Value: b})
+	return nil
+}
+
+// isKeptDocument returns true if value of Any is raw BSON document kept with UnknownAnyKeep policy,
+// i.e. valid document having type URL of Any under typeKey
+func (e *anyCodec) isKeptDocument(v *anypb.Any) bool {
+	if e.unknownPolicy != UnknownAnyKeep || bsoncore.Document(v.GetValue()).Validate() != nil {
+		return false
+	}
+	tv, err := bsoncore.Document(v.GetValue()).LookupErr(e.typeKey)
+	if err != nil {
+		return false
+	}
+	typeURL, ok := tv.StringValueOK()
+	return ok && typeURL == v.GetTypeUrl()
+}
+
+// decodeBinary decodes Any stored in binary form
+func (e *anyCodec) decodeBinary(doc []byte, val reflect.Value) error {
+	v := &anypb.Any{}
+	if tv, err := bsoncore.Document(doc).LookupErr(anyBinaryTypeURLKey); err == nil {
+		typeURL, ok := tv.StringValueOK()
+		if !ok {
+			return fmt.Errorf("key %q of Any must be string, but got %v", anyBinaryTypeURLKey, tv.Type)
+		}
+		v.TypeUrl = typeURL
+	}
+	if bv, err := bsoncore.Document(doc).LookupErr(anyBinaryValueKey); err == nil {
+		_, b, ok := bv.BinaryOK()
+		if !ok {
+			return fmt.Errorf("key %q of Any must be binary, but got %v", anyBinaryValueKey, bv.Type)
+		}
+		v.Value = b
+	}
+	setMessage(val, v)
+	return nil
+}
+
+// anyMessageType returns Go type of message for Any type URL or nil if type is unknown
+func anyMessageType(typeURL string) reflect.Type {
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil
+	}
+	// Generated message of legacy API is wrapped by APIv2 type registry
+	return reflect.TypeOf(protoadapt.MessageV1Of(mt.New().Interface()))
+}
+
+// isDocumentEncoded returns true if message of type t is stored as BSON document field by field
+// and false if message has own codec storing it as other BSON value (wrappers, Timestamp, etc.)
+func isDocumentEncoded(r *bsoncodec.Registry, t reflect.Type) (bool, error) {
+	enc, err := r.LookupEncoder(t)
+	if err != nil {
+		return false, err
+	}
+	return isStructCodec(enc), nil
+}
+
+// isStructCodec returns true if value codec stores Go struct as BSON document field by field
+func isStructCodec(c interface{}) bool {
+	switch c.(type) {
+	case *bsoncodec.StructCodec, *messageCodec:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package codecs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestAnyCodec(t *testing.T) {
+	rb := bson.NewRegistryBuilder()
+	r := Register(rb).Build()
+
+	inner, err := anypb.New(&test.Data{StringValue: &wrapperspb.StringValue{Value: "qwerty"}})
+	if err != nil {
+		t.Errorf("anypb.New error = %v", err)
+		return
+	}
+	duration, err := anypb.New(durationpb.New(time.Second))
+	if err != nil {
+		t.Errorf("anypb.New error = %v", err)
+		return
+	}
+
+	tests := []struct {
+		name    string
+		any     *anypb.Any
+		typeKey string
+	}{
+		{name: "message is stored as document", any: inner, typeKey: DefaultAnyTypeKey},
+		{name: "well-known type is stored as value", any: duration, typeKey: DefaultAnyTypeKey},
+		{name: "unknown type is stored as binary", any: &anypb.Any{
+			TypeUrl: "type.googleapis.com/unknown.Message", Value: []byte{1, 2, 3}}, typeKey: "typeurl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bson.MarshalWithRegistry(r, &test.Data{Any: tt.any})
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+
+			typeURL, err := bson.Raw(b).LookupErr("any", tt.typeKey)
+			if err != nil {
+				t.Errorf("bson.Raw.LookupErr error = %v", err)
+				return
+			}
+			if s, ok := typeURL.StringValueOK(); !ok || s != tt.any.TypeUrl {
+				t.Errorf("failed: type URL=%v", typeURL)
+				return
+			}
+
+			var out test.Data
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+
+			if out.Any.TypeUrl != tt.any.TypeUrl {
+				t.Errorf("failed: in=%v, out=%v", tt.any, out.Any)
+				return
+			}
+			if tt.typeKey == "typeurl" {
+				if !reflect.DeepEqual(tt.any.Value, out.Any.Value) {
+					t.Errorf("failed: in=%v, out=%v", tt.any, out.Any)
+				}
+				return
+			}
+
+			msgIn, err := tt.any.UnmarshalNew()
+			if err != nil {
+				t.Errorf("anypb.Any.UnmarshalNew error = %v", err)
+				return
+			}
+			msgOut, err := out.Any.UnmarshalNew()
+			if err != nil {
+				t.Errorf("anypb.Any.UnmarshalNew error = %v", err)
+				return
+			}
+			if !proto.Equal(msgIn, msgOut) {
+				t.Errorf("failed: in=%v, out=%v", msgIn, msgOut)
+				return
+			}
+		})
+	}
+
+	t.Run("unknown type document", func(t *testing.T) {
+		doc := bson.D{{Key: "any", Value: bson.D{
+			{Key: DefaultAnyTypeKey, Value: "type.googleapis.com/other.Message"},
+			{Key: "name", Value: "qwerty"},
+		}}}
+		b, err := bson.Marshal(doc)
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err == nil {
+			t.Errorf("bson.UnmarshalWithRegistry error expected for unknown type with UnknownAnyFail policy")
+			return
+		}
+
+		rk := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUnknownAnyPolicy(UnknownAnyKeep))).Build()
+		out = test.Data{}
+		if err = bson.UnmarshalWithRegistry(rk, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if out.Any.GetTypeUrl() != "type.googleapis.com/other.Message" {
+			t.Errorf("failed: any=%v", out.Any)
+			return
+		}
+		if b, err = bson.MarshalWithRegistry(rk, &out); err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		var result bson.D
+		if err = bson.Unmarshal(b, &result); err != nil {
+			t.Errorf("bson.Unmarshal error = %v", err)
+			return
+		}
+		if !reflect.DeepEqual(doc, result) {
+			t.Errorf("failed: expected=%v, result=%v", doc, result)
+			return
+		}
+	})
+}
@@ -1,54 +1,58 @@
 package codecs
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"reflect"
 	"time"
 
-	"github.com/golang/protobuf/ptypes"
-	"github.com/golang/protobuf/ptypes/timestamp"
-	"github.com/golang/protobuf/ptypes/wrappers"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-
-	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
 	// Protobuf wrappers types
-	boolValueType   = reflect.TypeOf(wrappers.BoolValue{})
-	bytesValueType  = reflect.TypeOf(wrappers.BytesValue{})
-	doubleValueType = reflect.TypeOf(wrappers.DoubleValue{})
-	floatValueType  = reflect.TypeOf(wrappers.FloatValue{})
-	int32ValueType  = reflect.TypeOf(wrappers.Int32Value{})
-	int64ValueType  = reflect.TypeOf(wrappers.Int64Value{})
-	stringValueType = reflect.TypeOf(wrappers.StringValue{})
-	uint32ValueType = reflect.TypeOf(wrappers.UInt32Value{})
-	uint64ValueType = reflect.TypeOf(wrappers.UInt64Value{})
-
-	// Protobuf Timestamp type
-	timestampType = reflect.TypeOf(timestamp.Timestamp{})
-
-	// Time type
-	timeType = reflect.TypeOf(time.Time{})
-
-	// ObjectId type
-	objectIDType          = reflect.TypeOf(pmongo.ObjectId{})
-	objectIDPrimitiveType = reflect.TypeOf(primitive.ObjectID{})
-
-	// Codecs
-	wrapperValueCodecRef = &wrapperValueCodec{}
-	timestampCodecRef    = &timestampCodec{}
-	objectIDCodecRef     = &objectIDCodec{}
+	boolValueType   = reflect.TypeOf(wrapperspb.BoolValue{})
+	bytesValueType  = reflect.TypeOf(wrapperspb.BytesValue{})
+	doubleValueType = reflect.TypeOf(wrapperspb.DoubleValue{})
+	floatValueType  = reflect.TypeOf(wrapperspb.FloatValue{})
+	int32ValueType  = reflect.TypeOf(wrapperspb.Int32Value{})
+	int64ValueType  = reflect.TypeOf(wrapperspb.Int64Value{})
+	stringValueType = reflect.TypeOf(wrapperspb.StringValue{})
+	uint32ValueType = reflect.TypeOf(wrapperspb.UInt32Value{})
+	uint64ValueType = reflect.TypeOf(wrapperspb.UInt64Value{})
+
+	// Protobuf Duration type
+	durationType = reflect.TypeOf(durationpb.Duration{})
+
+	// Int64 type
+	int64Type = reflect.TypeOf(int64(0))
 )
 
 // wrapperValueCodec is codec for Protobuf type wrappers
 type wrapperValueCodec struct {
+	uint64Storage Uint64Storage
 }
 
-// EncodeValue encodes Protobuf type wrapper value to BSON value
+// EncodeValue encodes Protobuf type wrapper value or pointer to it to BSON value.
+// Nil pointer is encoded as BSON null.
 func (e *wrapperValueCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
 	val = val.FieldByName("Value")
+	if val.Kind() == reflect.Uint64 {
+		return encodeUint64(vw, val.Uint(), e.uint64Storage)
+	}
 	enc, err := ectx.LookupEncoder(val.Type())
 	if err != nil {
 		return err
@@ -56,9 +60,27 @@ func (e *wrapperValueCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.
 	return enc.EncodeValue(ectx, vw, val)
 }
 
-// DecodeValue decodes BSON value to Protobuf type wrapper value
+// DecodeValue decodes BSON value to Protobuf type wrapper value or pointer to it.
+// BSON null and undefined are decoded as nil pointer to keep unset value distinct from zero value.
+// Numeric wrappers accept any BSON number which can be represented by wrapper value.
 func (e *wrapperValueCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "wrapperValueCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+	if isNullValue(vr.Type()) {
+		setNullValue(val)
+		return readNullValue(vr)
+	}
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
 	val = val.FieldByName("Value")
+	if isNumberKind(val.Kind()) {
+		return decodeNumber(vr, val)
+	}
 	enc, err := ectx.LookupDecoder(val.Type())
 	if err != nil {
 		return err
@@ -66,90 +88,193 @@ func (e *wrapperValueCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.
 	return enc.DecodeValue(ectx, vr, val)
 }
 
-// timestampCodec is codec for Protobuf Timestamp
-type timestampCodec struct {
+// durationCodec is codec for Protobuf Duration.
+// Duration is stored as BSON int64 value in units (nanoseconds by default) to be comparable in MongoDB queries.
+// Duration not being multiple of unit fails to encode instead of losing precision.
+type durationCodec struct {
+	unit time.Duration
 }
 
-// EncodeValue encodes Protobuf Timestamp value to BSON value
-func (e *timestampCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
-	v := val.Interface().(timestamp.Timestamp)
-	t, err := ptypes.Timestamp(&v)
-	if err != nil {
+// EncodeValue encodes Protobuf Duration value to BSON value
+func (e *durationCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	v := messageOf(val).(*durationpb.Duration)
+	if err := v.CheckValid(); err != nil {
 		return err
 	}
-	enc, err := ectx.LookupEncoder(timeType)
+	// AsDuration saturates values out of time.Duration range, so round trip is checked
+	d := v.AsDuration()
+	if !proto.Equal(durationpb.New(d), v) {
+		return fmt.Errorf("duration: %v is out of range for time.Duration", v)
+	}
+	enc, err := ectx.LookupEncoder(int64Type)
 	if err != nil {
 		return err
 	}
-	return enc.EncodeValue(ectx, vw, reflect.ValueOf(t.In(time.UTC)))
+	if d%e.unit != 0 {
+		return fmt.Errorf("duration: %v is not multiple of unit %v", d, e.unit)
+	}
+	return enc.EncodeValue(ectx, vw, reflect.ValueOf(int64(d/e.unit)))
 }
 
-// DecodeValue decodes BSON value to Timestamp value
-func (e *timestampCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
-	enc, err := ectx.LookupDecoder(timeType)
+// DecodeValue decodes BSON value to Duration value
+func (e *durationCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	enc, err := ectx.LookupDecoder(int64Type)
 	if err != nil {
 		return err
 	}
-	var t time.Time
-	if err = enc.DecodeValue(ectx, vr, reflect.ValueOf(&t).Elem()); err != nil {
+	var d int64
+	if err = enc.DecodeValue(ectx, vr, reflect.ValueOf(&d).Elem()); err != nil {
 		return err
 	}
-	ts, err := ptypes.TimestampProto(t.In(time.UTC))
-	if err != nil {
-		return err
+	if d > math.MaxInt64/int64(e.unit) || d < math.MinInt64/int64(e.unit) {
+		return fmt.Errorf("duration: %d*%v is out of range for time.Duration", d, e.unit)
 	}
-	val.Set(reflect.ValueOf(*ts))
+	setMessage(val, durationpb.New(time.Duration(d)*e.unit))
 	return nil
 }
 
-// objectIDCodec is codec for Protobuf ObjectId
-type objectIDCodec struct {
+// isNullValue returns true if BSON value type means absence of value
+func isNullValue(t bsontype.Type) bool {
+	return t == bsontype.Null || t == bsontype.Undefined
 }
 
-// EncodeValue encodes Protobuf ObjectId value to BSON value
-func (e *objectIDCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
-	v := val.Interface().(pmongo.ObjectId)
-	// Create primitive.ObjectId from string
-	id, err := primitive.ObjectIDFromHex(v.Value)
-	if err != nil {
-		return err
-	}
-	enc, err := ectx.LookupEncoder(objectIDPrimitiveType)
-	if err != nil {
-		return err
+// readNullValue reads BSON null or undefined value
+func readNullValue(vr bsonrw.ValueReader) error {
+	if vr.Type() == bsontype.Undefined {
+		return vr.ReadUndefined()
 	}
-	return enc.EncodeValue(ectx, vw, reflect.ValueOf(id))
+	return vr.ReadNull()
 }
 
-// DecodeValue decodes BSON value to ObjectId value
-func (e *objectIDCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
-	enc, err := ectx.LookupDecoder(objectIDPrimitiveType)
-	if err != nil {
-		return err
-	}
-	var id primitive.ObjectID
-	if err = enc.DecodeValue(ectx, vr, reflect.ValueOf(&id).Elem()); err != nil {
-		return err
+// setNullValue sets nil to settable message pointer val.
+// Message struct can't be nil, so it is reset to empty message instead.
+func setNullValue(val reflect.Value) {
+	if val.Kind() == reflect.Ptr {
+		val.Set(reflect.Zero(val.Type()))
+		return
 	}
-	oid := *pmongo.NewObjectId(id)
-	if err != nil {
-		return err
+	proto.Reset(messageOf(val))
+}
+
+// messageOf returns Protobuf message kept by struct value val.
+// Message is not copied if val is addressable.
+func messageOf(val reflect.Value) proto.Message {
+	if !val.CanAddr() {
+		p := reflect.New(val.Type())
+		p.Elem().Set(val)
+		val = p.Elem()
 	}
-	val.Set(reflect.ValueOf(oid))
-	return nil
+	return protoadapt.MessageV2Of(val.Addr().Interface().(protoadapt.MessageV1))
 }
 
-// Register registers Google protocol buffers types codecs
+// setMessage replaces Protobuf message kept by settable struct value val with m
+func setMessage(val reflect.Value, m proto.Message) {
+	dst := messageOf(val)
+	proto.Reset(dst)
+	proto.Merge(dst, m)
+}
+
+// Register registers Google protocol buffers types codecs with default options.
+// It replaces default struct codec as RegisterWithOptions does.
 func Register(rb *bsoncodec.RegistryBuilder) *bsoncodec.RegistryBuilder {
-	return rb.RegisterCodec(boolValueType, wrapperValueCodecRef).
-		RegisterCodec(bytesValueType, wrapperValueCodecRef).
-		RegisterCodec(doubleValueType, wrapperValueCodecRef).
-		RegisterCodec(floatValueType, wrapperValueCodecRef).
-		RegisterCodec(int32ValueType, wrapperValueCodecRef).
-		RegisterCodec(int64ValueType, wrapperValueCodecRef).
-		RegisterCodec(stringValueType, wrapperValueCodecRef).
-		RegisterCodec(uint32ValueType, wrapperValueCodecRef).
-		RegisterCodec(uint64ValueType, wrapperValueCodecRef).
-		RegisterCodec(timestampType, timestampCodecRef).
-		RegisterCodec(objectIDType, objectIDCodecRef)
+	return RegisterWithOptions(rb, nil)
+}
+
+// RegisterWithOptions registers Google protocol buffers types codecs configured by options.
+// Default options are used if opts is nil. Zero DurationUnit means nanoseconds.
+// Default struct codec of rb is replaced with one using NewProtoStructTagParser(opts.KeyNaming);
+// register own default struct encoder and decoder after this call to override it.
+func RegisterWithOptions(rb *bsoncodec.RegistryBuilder, opts *Options) *bsoncodec.RegistryBuilder {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	durationUnit := opts.DurationUnit
+	if durationUnit == 0 {
+		durationUnit = time.Nanosecond
+	}
+	if durationUnit < 0 {
+		panic(errors.New("codecs: duration unit must be positive"))
+	}
+	// Default struct codec skips protobuf runtime internals of generated structs
+	// and derives keys from protobuf struct tags the same way as message codec does
+	sc, err := bsoncodec.NewStructCodec(NewProtoStructTagParser(opts.KeyNaming))
+	if err != nil {
+		panic(err)
+	}
+	rb.RegisterDefaultEncoder(reflect.Struct, sc).
+		RegisterDefaultDecoder(reflect.Struct, sc)
+	if opts.Types&TypeWrappers != 0 {
+		c := &wrapperValueCodec{uint64Storage: opts.Uint64Storage}
+		for _, t := range []reflect.Type{boolValueType, bytesValueType, doubleValueType, floatValueType,
+			int32ValueType, int64ValueType, stringValueType, uint32ValueType, uint64ValueType} {
+			// Pointers are registered to decode BSON null and undefined as nil wrappers
+			rb.RegisterCodec(t, c).
+				RegisterCodec(reflect.PtrTo(t), c)
+		}
+	}
+	if opts.Types&TypeTimestamp != 0 {
+		c := &timestampCodec{
+			precision:   opts.TimestampPrecision,
+			storage:     opts.TimestampStorage,
+			rangePolicy: opts.TimestampRangePolicy,
+		}
+		rb.RegisterCodec(timestampType, c).
+			RegisterCodec(timestampPtrType, c)
+	}
+	if opts.Types&TypeDuration != 0 {
+		rb.RegisterCodec(durationType, &durationCodec{unit: durationUnit})
+	}
+	if opts.Types&TypeStruct != 0 {
+		rb.RegisterCodec(structType, structCodecRef).
+			RegisterCodec(valueType, valueCodecRef).
+			RegisterCodec(listValueType, listValueCodecRef)
+	}
+	if opts.Types&TypeAny != 0 {
+		rb.RegisterCodec(anyType, &anyCodec{typeKey: opts.AnyTypeKey, unknownPolicy: opts.UnknownAnyPolicy})
+	}
+	if opts.Types&TypeObjectID != 0 {
+		oc := &objectIDCodec{emptyPolicy: opts.EmptyObjectIDPolicy}
+		bc := &binaryObjectIDCodec{emptyPolicy: opts.EmptyObjectIDPolicy}
+		// Pointers are registered to decode BSON null as nil and to report empty ObjectId as zero value
+		rb.RegisterCodec(objectIDType, oc).
+			RegisterCodec(reflect.PtrTo(objectIDType), oc).
+			RegisterCodec(binaryObjectIDType, bc).
+			RegisterCodec(reflect.PtrTo(binaryObjectIDType), bc)
+	}
+	if opts.Types&TypeDecimal128 != 0 {
+		c := &decimal128Codec{}
+		rb.RegisterCodec(decimal128Type, c).
+			RegisterCodec(reflect.PtrTo(decimal128Type), c)
+	}
+	if opts.Types&TypeUUID != 0 {
+		c := &uuidCodec{representation: opts.UUIDRepresentation}
+		rb.RegisterCodec(uuidType, c).
+			RegisterCodec(reflect.PtrTo(uuidType), c)
+	}
+	if opts.Types&TypePrimitive != 0 {
+		for t, c := range primitiveCodecs() {
+			rb.RegisterCodec(t, c).
+				RegisterCodec(reflect.PtrTo(t), c)
+		}
+		c := &dbRefCodec{}
+		rb.RegisterCodec(dbRefType, c).
+			RegisterCodec(reflect.PtrTo(dbRefType), c)
+	}
+	if opts.Types&TypeGeoJSON != 0 {
+		c := &geoJSONCodec{}
+		for _, t := range geoJSONGeometries {
+			rb.RegisterCodec(t, c).
+				RegisterCodec(reflect.PtrTo(t), c)
+		}
+	}
+	if opts.Types&TypeEnum != 0 {
+		rb.RegisterCodec(protoEnumType, &enumCodec{
+			representation: opts.EnumRepresentation,
+			unknownPolicy:  opts.UnknownEnumPolicy,
+		})
+	}
+	if opts.Types&TypeMessage != 0 {
+		rb.RegisterCodec(protoMessageType, newMessageCodec(opts))
+	}
+	return rb
 }
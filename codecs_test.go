@@ -2,15 +2,24 @@ package codecs
 
 import (
 	"bytes"
+	"errors"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/jsonpb"
-	"github.com/golang/protobuf/ptypes"
-	"github.com/golang/protobuf/ptypes/wrappers"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
 	"github.com/amsokol/mongo-go-driver-protobuf/test"
@@ -25,11 +34,9 @@ func TestCodecs(t *testing.T) {
 	tm = time.Date(tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(),
 		(tm.Nanosecond()/1000000)*1000000, tm.Location())
 
-	ts, err := ptypes.TimestampProto(tm)
-	if err != nil {
-		t.Errorf("ptypes.TimestampProto error = %v", err)
-		return
-	}
+	ts := timestamppb.New(tm)
+
+	d := durationpb.New(90 * time.Minute)
 
 	objectID := primitive.NewObjectID()
 	id := pmongo.NewObjectId(objectID)
@@ -47,22 +54,40 @@ func TestCodecs(t *testing.T) {
 		}
 	})
 
-	in := test.Data{
-		BoolValue:   &wrappers.BoolValue{Value: true},
-		BytesValue:  &wrappers.BytesValue{Value: make([]byte, 5)},
-		DoubleValue: &wrappers.DoubleValue{Value: 1.2},
-		FloatValue:  &wrappers.FloatValue{Value: 1.3},
-		Int32Value:  &wrappers.Int32Value{Value: -12345},
-		Int64Value:  &wrappers.Int64Value{Value: -123456789},
-		StringValue: &wrappers.StringValue{Value: "qwerty"},
-		Uint32Value: &wrappers.UInt32Value{Value: 12345},
-		Uint64Value: &wrappers.UInt64Value{Value: 123456789},
+	in := &test.Data{
+		BoolValue:   &wrapperspb.BoolValue{Value: true},
+		BytesValue:  &wrapperspb.BytesValue{Value: make([]byte, 5)},
+		DoubleValue: &wrapperspb.DoubleValue{Value: 1.2},
+		FloatValue:  &wrapperspb.FloatValue{Value: 1.3},
+		Int32Value:  &wrapperspb.Int32Value{Value: -12345},
+		Int64Value:  &wrapperspb.Int64Value{Value: -123456789},
+		StringValue: &wrapperspb.StringValue{Value: "qwerty"},
+		Uint32Value: &wrapperspb.UInt32Value{Value: 12345},
+		Uint64Value: &wrapperspb.UInt64Value{Value: 123456789},
 		Timestamp:   ts,
 		Id:          id,
+		BinaryId:    pmongo.NewBinaryObjectId(objectID),
+		Duration:    d,
+		Struct: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"name":  {Kind: &structpb.Value_StringValue{StringValue: "qwerty"}},
+			"count": {Kind: &structpb.Value_NumberValue{NumberValue: 12}},
+			"empty": {Kind: &structpb.Value_NullValue{}},
+			"tags": {Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: []*structpb.Value{
+				{Kind: &structpb.Value_BoolValue{BoolValue: true}},
+				{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"nested": {Kind: &structpb.Value_StringValue{StringValue: "value"}},
+				}}}},
+			}}}},
+		}},
+		Value: &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: 1.5}},
+		List: &structpb.ListValue{Values: []*structpb.Value{
+			{Kind: &structpb.Value_StringValue{StringValue: "a"}},
+			{Kind: &structpb.Value_NullValue{}},
+		}},
 	}
 
 	t.Run("marshal/unmarshal", func(t *testing.T) {
-		b, err := bson.MarshalWithRegistry(r, &in)
+		b, err := bson.MarshalWithRegistry(r, in)
 		if err != nil {
 			t.Errorf("bson.MarshalWithRegistry error = %v", err)
 			return
@@ -75,8 +100,54 @@ func TestCodecs(t *testing.T) {
 			return
 		}
 
-		if !reflect.DeepEqual(in, out) {
-			t.Errorf("failed: in=%#v, out=%#v", in, out)
+		if !proto.Equal(in, &out) {
+			t.Errorf("failed: in=%v, out=%v", in, &out)
+			return
+		}
+	})
+
+	t.Run("duration is stored in nanoseconds", func(t *testing.T) {
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+
+		v, err := bson.Raw(b).LookupErr("duration")
+		if err != nil {
+			t.Errorf("bson.Raw.LookupErr error = %v", err)
+			return
+		}
+
+		if ns, ok := v.Int64OK(); !ok || ns != int64(90*time.Minute) {
+			t.Errorf("failed: duration=%v", v)
+			return
+		}
+	})
+
+	t.Run("binary object id is stored as ObjectID", func(t *testing.T) {
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+
+		v, err := bson.Raw(b).LookupErr("binary_id")
+		if err != nil {
+			t.Errorf("bson.Raw.LookupErr error = %v", err)
+			return
+		}
+
+		if oid, ok := v.ObjectIDOK(); !ok || oid != objectID {
+			t.Errorf("failed: binary_id=%v, expected=%v", v, objectID)
+			return
+		}
+	})
+
+	t.Run("duration out of range", func(t *testing.T) {
+		bad := &test.Data{Duration: &durationpb.Duration{Seconds: math.MaxInt64}}
+		if _, err := bson.MarshalWithRegistry(r, bad); err == nil {
+			t.Errorf("bson.MarshalWithRegistry error expected for duration=%v", bad.Duration)
 			return
 		}
 	})
@@ -86,20 +157,155 @@ func TestCodecs(t *testing.T) {
 
 		m := &jsonpb.Marshaler{}
 
-		if err := m.Marshal(&b, &in); err != nil {
+		if err := m.Marshal(&b, in); err != nil {
 			t.Errorf("jsonpb.Marshaler.Marshal error = %v", err)
 			return
 		}
 
 		var out test.Data
-		if err = jsonpb.Unmarshal(&b, &out); err != nil {
+		if err := jsonpb.Unmarshal(&b, &out); err != nil {
 			t.Errorf("jsonpb.Unmarshal error = %v", err)
 			return
 		}
 
-		if !reflect.DeepEqual(in, out) {
-			t.Errorf("failed: in=%#v, out=%#v", in, out)
+		if !proto.Equal(in, &out) {
+			t.Errorf("failed: in=%v, out=%v", in, &out)
+			return
+		}
+	})
+	t.Run("marshal-protojson/unmarshal-protojson", func(t *testing.T) {
+		b, err := protojson.Marshal(in)
+		if err != nil {
+			t.Errorf("protojson.Marshal error = %v", err)
+			return
+		}
+
+		var out test.Data
+		if err = protojson.Unmarshal(b, &out); err != nil {
+			t.Errorf("protojson.Unmarshal error = %v", err)
+			return
+		}
+
+		if !proto.Equal(in, &out) {
+			t.Errorf("failed: in=%v, out=%v", in, &out)
 			return
 		}
 	})
 }
+
+func TestNullHandling(t *testing.T) {
+	for _, types := range []Types{TypeAll, TypeAll &^ TypeMessage} {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTypes(types))).Build()
+		// Default struct codec uses proto field names as keys the same way as message codec does
+		key := "int32Value"
+
+		for _, v := range []interface{}{nil, primitive.Undefined{}} {
+			b, err := bson.Marshal(bson.D{
+				{Key: key, Value: v},
+				{Key: "timestamp", Value: v},
+			})
+			if err != nil {
+				t.Errorf("bson.Marshal error = %v", err)
+				return
+			}
+
+			out := test.Data{
+				Int32Value: &wrapperspb.Int32Value{Value: 1},
+				Timestamp:  timestamppb.Now(),
+			}
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if out.Int32Value != nil || out.Timestamp != nil {
+				t.Errorf("failed: types=%b, value=%#v, out=%v", types, v, &out)
+				return
+			}
+		}
+	}
+
+	t.Run("absent value is nil", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		var out test.Data
+		if err := bson.UnmarshalWithRegistry(r, []byte{5, 0, 0, 0, 0}, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if out.Int32Value != nil || out.Timestamp != nil {
+			t.Errorf("failed: out=%v", &out)
+			return
+		}
+	})
+
+	t.Run("zero value is not null", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithNilPolicy(NilNull))).Build()
+
+		b, err := bson.MarshalWithRegistry(r, &test.Data{Int32Value: &wrapperspb.Int32Value{}})
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if v, err := bson.Raw(b).LookupErr("int32Value"); err != nil || v.Type != bsontype.Int32 {
+			t.Errorf("failed: int32Value=%v, error=%v", v, err)
+			return
+		}
+		if v, err := bson.Raw(b).LookupErr("int64Value"); err != nil || v.Type != bsontype.Null {
+			t.Errorf("failed: int64Value=%v, error=%v", v, err)
+			return
+		}
+
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if out.Int32Value == nil || out.Int64Value != nil {
+			t.Errorf("failed: out=%v", &out)
+			return
+		}
+	})
+}
+
+// failCase is test case failing to encode message in or, if in is nil, to decode document doc into out
+type failCase struct {
+	name string
+	in   interface{}
+	doc  bson.D
+	out  interface{}
+	// target is pointer to typed error errors.As must find or nil for untyped error
+	target interface{}
+	// reason is text error message must contain
+	reason string
+}
+
+// runFailCases runs fail cases with registry r
+func runFailCases(t *testing.T, r *bsoncodec.Registry, tests []failCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.in != nil {
+				_, err = bson.MarshalWithRegistry(r, tt.in)
+			} else {
+				err = decodeDocument(r, tt.doc, tt.out)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("failed: error=%v, error containing %q expected", err, tt.reason)
+				return
+			}
+			if tt.target != nil && !errors.As(err, tt.target) {
+				t.Errorf("failed: error=%v, %v expected", err, reflect.TypeOf(tt.target).Elem())
+				return
+			}
+		})
+	}
+}
+
+// decodeDocument marshals doc and decodes it into out with registry r
+func decodeDocument(r *bsoncodec.Registry, doc bson.D, out interface{}) error {
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return bson.UnmarshalWithRegistry(r, b, out)
+}
@@ -0,0 +1,96 @@
+package codecs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+)
+
+var (
+	// Decimal128 type
+	decimal128Type = reflect.TypeOf(pmongo.Decimal128{})
+)
+
+// decimal128Codec is codec for Protobuf Decimal128
+type decimal128Codec struct {
+}
+
+// EncodeValue encodes Protobuf Decimal128 value to BSON Decimal128 value
+func (e *decimal128Codec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	v := messageOf(val).(*pmongo.Decimal128)
+	return vw.WriteDecimal128(v.GetDecimal128())
+}
+
+// DecodeValue decodes BSON Decimal128 or any other BSON number to Protobuf Decimal128 value
+func (e *decimal128Codec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "decimal128Codec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+	if isNullValue(vr.Type()) {
+		setNullValue(val)
+		return readNullValue(vr)
+	}
+	d, err := readDecimal128(vr)
+	if err != nil {
+		return err
+	}
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	setMessage(val, pmongo.NewDecimal128(d))
+	return nil
+}
+
+// readDecimal128 reads BSON Decimal128 as is and converts int32, int64 and double to Decimal128 exactly
+// the way they are written in decimal, e.g. double 0.1 is read as 0.1
+func readDecimal128(vr bsonrw.ValueReader) (primitive.Decimal128, error) {
+	var s string
+	switch vr.Type() {
+	case bsontype.Decimal128:
+		return vr.ReadDecimal128()
+	case bsontype.Int32:
+		v, err := vr.ReadInt32()
+		if err != nil {
+			return primitive.Decimal128{}, err
+		}
+		s = strconv.FormatInt(int64(v), 10)
+	case bsontype.Int64:
+		v, err := vr.ReadInt64()
+		if err != nil {
+			return primitive.Decimal128{}, err
+		}
+		s = strconv.FormatInt(v, 10)
+	case bsontype.Double:
+		v, err := vr.ReadDouble()
+		if err != nil {
+			return primitive.Decimal128{}, err
+		}
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case bsontype.String:
+		// Decimal string is not BSON number, so it can't be compared with numbers in MongoDB queries
+		v, err := vr.ReadString()
+		if err != nil {
+			return primitive.Decimal128{}, err
+		}
+		return primitive.Decimal128{}, &NumberError{Value: strconv.Quote(v), Kind: reflect.Struct, Reason: "string is not BSON number"}
+	default:
+		return primitive.Decimal128{}, fmt.Errorf("cannot decode %v into Decimal128", vr.Type())
+	}
+	return primitive.ParseDecimal128(s)
+}
@@ -0,0 +1,70 @@
+package codecs
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestDecimal128Codec(t *testing.T) {
+	r := Register(bson.NewRegistryBuilder()).Build()
+	amount, _ := pmongo.ParseDecimal128("1234567890.0123456789")
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		expect string
+	}{
+		{name: "decimal128", value: amount.GetDecimal128(), expect: "1234567890.0123456789"},
+		{name: "int32", value: int32(-7), expect: "-7"},
+		{name: "int64", value: int64(1) << 40, expect: "1099511627776"},
+		{name: "double", value: 0.1, expect: "0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out test.Data
+			if err := decodeDocument(r, bson.D{{Key: "amount", Value: tt.value}}, &out); err != nil {
+				t.Errorf("decodeDocument error = %v", err)
+				return
+			}
+			if s := out.GetAmount().GetDecimal128().String(); s != tt.expect {
+				t.Errorf("failed: amount=%s, expected=%s", s, tt.expect)
+				return
+			}
+
+			// Any number is written back as BSON Decimal128
+			b, err := bson.MarshalWithRegistry(r, &out)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			v, err := bson.Raw(b).LookupErr("amount")
+			if err != nil || v.Type != bsontype.Decimal128 || v.Decimal128().String() != tt.expect {
+				t.Errorf("failed: amount=%v, error=%v", v, err)
+				return
+			}
+		})
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		runFailCases(t, r, []failCase{
+			{
+				name:   "string",
+				doc:    bson.D{{Key: "amount", Value: "1.5"}},
+				out:    &test.Data{},
+				target: new(*NumberError),
+				reason: `field amount: cannot decode number "1.5"`,
+			},
+			{
+				name:   "boolean",
+				doc:    bson.D{{Key: "amount", Value: true}},
+				out:    &test.Data{},
+				reason: "cannot decode boolean into Decimal128",
+			},
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package codecs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// Protobuf enum interface type.
+	// Enums generated for APIv2 implement it.
+	protoEnumType = reflect.TypeOf((*protoreflect.Enum)(nil)).Elem()
+)
+
+// EnumError is error of enum value unknown to enum declaration
+type EnumError struct {
+	// Path is dot separated path of message field having the value, e.g. "colors.1".
+	// It is empty if value is not encoded or decoded as part of Protobuf message.
+	Path string
+	// Enum is full name of proto enum
+	Enum string
+	// Value is unknown enum name or number
+	Value string
+}
+
+// Error returns error message
+func (e *EnumError) Error() string {
+	msg := fmt.Sprintf("unknown value %s of enum %s", e.Value, e.Enum)
+	if e.Path != "" {
+		msg = fmt.Sprintf("field %s: %s", e.Path, msg)
+	}
+	return msg
+}
+
+// addPathElement adds field name or index to the beginning of path
+func (e *EnumError) addPathElement(elem string) {
+	e.Path = prependPathElement(e.Path, elem)
+}
+
+// enumCodec is codec for Protobuf enums registered as hook for Protobuf enum interface.
+// Enum values are stored as BSON int32 numbers or strings with proto names,
+// both of them are accepted on decode.
+type enumCodec struct {
+	representation EnumRepresentation
+	unknownPolicy  UnknownEnumPolicy
+}
+
+// EncodeValue encodes Protobuf enum value to BSON value
+func (e *enumCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	v, ok := val.Interface().(protoreflect.Enum)
+	if !ok {
+		return bsoncodec.ValueEncoderError{Name: "enumCodec.EncodeValue", Types: []reflect.Type{protoEnumType}, Received: val}
+	}
+	return encodeEnum(vw, v.Descriptor(), v.Number(), e.representation, e.unknownPolicy)
+}
+
+// DecodeValue decodes BSON value to Protobuf enum value
+func (e *enumCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "enumCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Int32}, Received: val}
+	}
+	if val.Kind() == reflect.Ptr {
+		if isNullValue(vr.Type()) {
+			val.Set(reflect.Zero(val.Type()))
+			return readNullValue(vr)
+		}
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	v, ok := val.Interface().(protoreflect.Enum)
+	if !ok {
+		return bsoncodec.ValueDecoderError{Name: "enumCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Int32}, Received: val}
+	}
+	n, err := decodeEnum(vr, v.Descriptor(), e.unknownPolicy)
+	if err != nil {
+		return err
+	}
+	val.SetInt(int64(n))
+	return nil
+}
+
+// encodeEnum writes enum value as BSON int32 number or string with proto name.
+// Values unknown to enum are handled according to policy, kept values are written as numbers.
+func encodeEnum(vw bsonrw.ValueWriter, ed protoreflect.EnumDescriptor, n protoreflect.EnumNumber,
+	representation EnumRepresentation, policy UnknownEnumPolicy) error {
+	ev := ed.Values().ByNumber(n)
+	if ev == nil {
+		switch policy {
+		case UnknownEnumFail:
+			return &EnumError{Enum: string(ed.FullName()), Value: strconv.Itoa(int(n))}
+		case UnknownEnumZero:
+			ev = ed.Values().Get(0)
+			n = ev.Number()
+		}
+	}
+	if ev != nil && representation == EnumName {
+		return vw.WriteString(string(ev.Name()))
+	}
+	return vw.WriteInt32(int32(n))
+}
+
+// decodeEnum reads enum value from BSON string with proto name or any BSON number.
+// Values unknown to enum are handled according to policy, unknown names can't be kept and fail.
+func decodeEnum(vr bsonrw.ValueReader, ed protoreflect.EnumDescriptor, policy UnknownEnumPolicy) (protoreflect.EnumNumber, error) {
+	if vr.Type() == bsontype.String {
+		name, err := vr.ReadString()
+		if err != nil {
+			return 0, err
+		}
+		if ev := ed.Values().ByName(protoreflect.Name(name)); ev != nil {
+			return ev.Number(), nil
+		}
+		if policy == UnknownEnumZero {
+			return ed.Values().Get(0).Number(), nil
+		}
+		return 0, &EnumError{Enum: string(ed.FullName()), Value: strconv.Quote(name)}
+	}
+	var n int32
+	if err := decodeNumber(vr, reflect.ValueOf(&n).Elem()); err != nil {
+		return 0, err
+	}
+	if ed.Values().ByNumber(protoreflect.EnumNumber(n)) == nil {
+		switch policy {
+		case UnknownEnumFail:
+			return 0, &EnumError{Enum: string(ed.FullName()), Value: strconv.Itoa(int(n))}
+		case UnknownEnumZero:
+			return ed.Values().Get(0).Number(), nil
+		}
+	}
+	return protoreflect.EnumNumber(n), nil
+}
@@ -0,0 +1,143 @@
+package codecs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+// enumHolder is Go struct having enum fields encoded by default struct codec
+type enumHolder struct {
+	Color    test.Color
+	ColorPtr *test.Color
+}
+
+func TestEnumCodec(t *testing.T) {
+	red := test.Color_RED
+
+	t.Run("names", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithEnumRepresentation(EnumName))).Build()
+
+		in := enumHolder{Color: test.Color_GREEN, ColorPtr: &red}
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if v := bson.Raw(b).Lookup("color"); v.StringValue() != "GREEN" {
+			t.Errorf("failed: color=%v", v)
+			return
+		}
+		if v := bson.Raw(b).Lookup("colorptr"); v.StringValue() != "RED" {
+			t.Errorf("failed: colorptr=%v", v)
+			return
+		}
+
+		var out enumHolder
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if !reflect.DeepEqual(in, out) {
+			t.Errorf("failed: in=%v, out=%v", in, out)
+			return
+		}
+	})
+
+	t.Run("decode names or numbers", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), nil).Build()
+
+		b, err := bson.Marshal(bson.D{{Key: "color", Value: "GREEN"}, {Key: "colorptr", Value: 1.0}})
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+		var out enumHolder
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if out.Color != test.Color_GREEN || out.ColorPtr == nil || *out.ColorPtr != test.Color_RED {
+			t.Errorf("failed: out=%v", out)
+			return
+		}
+	})
+
+	tests := []struct {
+		name   string
+		policy UnknownEnumPolicy
+		doc    bson.D
+		expect []test.Color
+		fail   bool
+	}{
+		{
+			name:   "keep unknown number",
+			policy: UnknownEnumKeep,
+			doc:    bson.D{{Key: "colors", Value: bson.A{int32(5)}}},
+			expect: []test.Color{5},
+		},
+		{
+			name:   "keep fails on unknown name",
+			policy: UnknownEnumKeep,
+			doc:    bson.D{{Key: "colors", Value: bson.A{"BLUE"}}},
+			fail:   true,
+		},
+		{
+			name:   "fail on unknown number",
+			policy: UnknownEnumFail,
+			doc:    bson.D{{Key: "colors", Value: bson.A{"RED", int32(5)}}},
+			fail:   true,
+		},
+		{
+			name:   "zero",
+			policy: UnknownEnumZero,
+			doc:    bson.D{{Key: "colors", Value: bson.A{"RED", int32(5), "BLUE"}}},
+			expect: []test.Color{test.Color_RED, test.Color_COLOR_UNSPECIFIED, test.Color_COLOR_UNSPECIFIED},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUnknownEnumPolicy(tt.policy))).Build()
+
+			b, err := bson.Marshal(tt.doc)
+			if err != nil {
+				t.Errorf("bson.Marshal error = %v", err)
+				return
+			}
+			var out test.Data
+			err = bson.UnmarshalWithRegistry(r, b, &out)
+			if tt.fail {
+				var e *EnumError
+				if !errors.As(err, &e) || e.Path != "colors.0" && e.Path != "colors.1" {
+					t.Errorf("bson.UnmarshalWithRegistry error = %v, *EnumError having path expected", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.expect, out.Colors) {
+				t.Errorf("failed: expected=%v, colors=%v", tt.expect, out.Colors)
+				return
+			}
+		})
+	}
+
+	t.Run("encode unknown", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUnknownEnumPolicy(UnknownEnumFail))).Build()
+
+		if _, err := bson.MarshalWithRegistry(r, &test.Data{Color: 5}); err == nil {
+			t.Errorf("bson.MarshalWithRegistry error expected for unknown enum value")
+			return
+		}
+		if _, err := bson.MarshalWithRegistry(r, enumHolder{Color: 5}); err == nil {
+			t.Errorf("bson.MarshalWithRegistry error expected for unknown enum value")
+			return
+		}
+	})
+}
@@ -0,0 +1,392 @@
+package codecs
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+)
+
+const (
+	// BSON keys of GeoJSON document
+	geoJSONTypeKey        = "type"
+	geoJSONCoordinatesKey = "coordinates"
+	geoJSONGeometriesKey  = "geometries"
+)
+
+var (
+	// GeoJSON geometry types
+	geometryType      = reflect.TypeOf(pmongo.Geometry{})
+	geoJSONGeometries = []reflect.Type{
+		reflect.TypeOf(pmongo.Point{}),
+		reflect.TypeOf(pmongo.LineString{}),
+		reflect.TypeOf(pmongo.Polygon{}),
+		reflect.TypeOf(pmongo.MultiPoint{}),
+		reflect.TypeOf(pmongo.MultiLineString{}),
+		reflect.TypeOf(pmongo.MultiPolygon{}),
+		reflect.TypeOf(pmongo.GeometryCollection{}),
+		geometryType,
+	}
+)
+
+// geoJSONCodec is codec for pmongo GeoJSON geometries stored as GeoJSON documents
+// {type, coordinates} or {type: "GeometryCollection", geometries} to be used with 2dsphere indexes.
+// Geometries are validated on both encode and decode.
+type geoJSONCodec struct {
+}
+
+// EncodeValue encodes pmongo GeoJSON geometry value to GeoJSON document
+func (e *geoJSONCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	g := messageOf(val).(pmongo.GeoJSONGeometry)
+	if err := g.Validate(); err != nil {
+		return err
+	}
+	return encodeGeometry(vw, g)
+}
+
+// DecodeValue decodes GeoJSON document to pmongo GeoJSON geometry value.
+// Geometry of any type is decoded to Geometry, other messages accept geometries of their type only.
+func (e *geoJSONCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "geoJSONCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+	if isNullValue(vr.Type()) {
+		setNullValue(val)
+		return readNullValue(vr)
+	}
+	g, err := decodeGeometry(vr)
+	if err != nil {
+		return err
+	}
+	if err = g.Validate(); err != nil {
+		return err
+	}
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	if val.Type() == geometryType {
+		if g, err = pmongo.NewGeometry(g); err != nil {
+			return err
+		}
+	} else if reflect.TypeOf(g).Elem() != val.Type() {
+		return fmt.Errorf("cannot decode GeoJSON %s into %v", g.GeoJSONType(), val.Type())
+	}
+	setMessage(val, g)
+	return nil
+}
+
+// encodeGeometry writes GeoJSON document of valid geometry
+func encodeGeometry(vw bsonrw.ValueWriter, g pmongo.GeoJSONGeometry) error {
+	if v, ok := g.(*pmongo.Geometry); ok {
+		g = v.GetGeoJSONGeometry()
+	}
+	if g == nil || reflect.ValueOf(g).IsNil() {
+		return &pmongo.InvalidGeoJSONError{Type: "geometry", Reason: "geometry is not set"}
+	}
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	evw, err := dw.WriteDocumentElement(geoJSONTypeKey)
+	if err != nil {
+		return err
+	}
+	if err = evw.WriteString(g.GeoJSONType()); err != nil {
+		return err
+	}
+	if c, ok := g.(*pmongo.GeometryCollection); ok {
+		if evw, err = dw.WriteDocumentElement(geoJSONGeometriesKey); err != nil {
+			return err
+		}
+		err = writeArray(evw, len(c.Geometries), func(i int, vw bsonrw.ValueWriter) error {
+			if c.Geometries[i].GetGeoJSONGeometry() == nil {
+				return &pmongo.InvalidGeoJSONError{
+					Type:   pmongo.GeoJSONGeometryCollection,
+					Path:   fmt.Sprintf("%s.%d", geoJSONGeometriesKey, i),
+					Reason: "geometry is not set",
+				}
+			}
+			return encodeGeometry(vw, c.Geometries[i])
+		})
+		if err != nil {
+			return err
+		}
+		return dw.WriteDocumentEnd()
+	}
+	if evw, err = dw.WriteDocumentElement(geoJSONCoordinatesKey); err != nil {
+		return err
+	}
+	switch v := g.(type) {
+	case *pmongo.Point:
+		err = writePosition(evw, v.Coordinates)
+	case *pmongo.LineString:
+		err = writePositions(evw, v.Coordinates)
+	case *pmongo.Polygon:
+		err = writeRings(evw, v.Coordinates)
+	case *pmongo.MultiPoint:
+		err = writePositions(evw, v.Coordinates)
+	case *pmongo.MultiLineString:
+		err = writeArray(evw, len(v.Coordinates), func(i int, vw bsonrw.ValueWriter) error {
+			return writePositions(vw, v.Coordinates[i].GetCoordinates())
+		})
+	case *pmongo.MultiPolygon:
+		err = writeArray(evw, len(v.Coordinates), func(i int, vw bsonrw.ValueWriter) error {
+			return writeRings(vw, v.Coordinates[i].GetCoordinates())
+		})
+	}
+	if err != nil {
+		return err
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// writeArray writes BSON array of n elements written by write
+func writeArray(vw bsonrw.ValueWriter, n int, write func(i int, vw bsonrw.ValueWriter) error) error {
+	aw, err := vw.WriteArray()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		evw, err := aw.WriteArrayElement()
+		if err != nil {
+			return err
+		}
+		if err = write(i, evw); err != nil {
+			return err
+		}
+	}
+	return aw.WriteArrayEnd()
+}
+
+// writePosition writes GeoJSON position as [longitude, latitude] array
+func writePosition(vw bsonrw.ValueWriter, p *pmongo.Position) error {
+	return writeArray(vw, 2, func(i int, vw bsonrw.ValueWriter) error {
+		if i == 0 {
+			return vw.WriteDouble(p.Longitude)
+		}
+		return vw.WriteDouble(p.Latitude)
+	})
+}
+
+// writePositions writes array of GeoJSON positions
+func writePositions(vw bsonrw.ValueWriter, ps []*pmongo.Position) error {
+	return writeArray(vw, len(ps), func(i int, vw bsonrw.ValueWriter) error {
+		return writePosition(vw, ps[i])
+	})
+}
+
+// writeRings writes array of polygon linear rings
+func writeRings(vw bsonrw.ValueWriter, rings []*pmongo.LinearRing) error {
+	return writeArray(vw, len(rings), func(i int, vw bsonrw.ValueWriter) error {
+		return writePositions(vw, rings[i].GetCoordinates())
+	})
+}
+
+// coordinates is GeoJSON coordinates read before geometry type is known:
+// number or array of nested coordinates
+type coordinates struct {
+	number float64
+	array  []*coordinates
+}
+
+// decodeGeometry reads GeoJSON document of any geometry type. Keys other than
+// type, coordinates and geometries (e.g. bbox) are skipped.
+func decodeGeometry(vr bsonrw.ValueReader) (pmongo.GeoJSONGeometry, error) {
+	switch vr.Type() {
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	default:
+		return nil, fmt.Errorf("cannot decode %v into GeoJSON geometry", vr.Type())
+	}
+	dr, err := vr.ReadDocument()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		typ        string
+		coords     *coordinates
+		geometries []pmongo.GeoJSONGeometry
+	)
+	for {
+		key, evr, err := dr.ReadElement()
+		if err == bsonrw.ErrEOD {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case geoJSONTypeKey:
+			if evr.Type() != bsontype.String {
+				return nil, fmt.Errorf("GeoJSON key %q must be string, but got %v", key, evr.Type())
+			}
+			if typ, err = evr.ReadString(); err != nil {
+				return nil, err
+			}
+		case geoJSONCoordinatesKey:
+			if coords, err = readCoordinates(evr); err != nil {
+				return nil, err
+			}
+		case geoJSONGeometriesKey:
+			if evr.Type() != bsontype.Array {
+				return nil, fmt.Errorf("GeoJSON key %q must be array, but got %v", key, evr.Type())
+			}
+			ar, err := evr.ReadArray()
+			if err != nil {
+				return nil, err
+			}
+			for {
+				avr, err := ar.ReadValue()
+				if err == bsonrw.ErrEOA {
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+				g, err := decodeGeometry(avr)
+				if err != nil {
+					return nil, err
+				}
+				geometries = append(geometries, g)
+			}
+		default:
+			if err = evr.Skip(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if typ == pmongo.GeoJSONGeometryCollection {
+		c := &pmongo.GeometryCollection{}
+		for _, g := range geometries {
+			v, err := pmongo.NewGeometry(g)
+			if err != nil {
+				return nil, err
+			}
+			c.Geometries = append(c.Geometries, v)
+		}
+		return c, nil
+	}
+	if coords == nil {
+		return nil, fmt.Errorf("GeoJSON %s has no %q key", typ, geoJSONCoordinatesKey)
+	}
+	switch typ {
+	case pmongo.GeoJSONPoint:
+		p, err := coords.position()
+		return &pmongo.Point{Coordinates: p}, err
+	case pmongo.GeoJSONLineString:
+		ps, err := coords.positions()
+		return &pmongo.LineString{Coordinates: ps}, err
+	case pmongo.GeoJSONPolygon:
+		rings, err := coords.rings()
+		return &pmongo.Polygon{Coordinates: rings}, err
+	case pmongo.GeoJSONMultiPoint:
+		ps, err := coords.positions()
+		return &pmongo.MultiPoint{Coordinates: ps}, err
+	case pmongo.GeoJSONMultiLineString:
+		m := &pmongo.MultiLineString{}
+		err := coords.forEach(func(c *coordinates) error {
+			ps, err := c.positions()
+			m.Coordinates = append(m.Coordinates, &pmongo.LineString{Coordinates: ps})
+			return err
+		})
+		return m, err
+	case pmongo.GeoJSONMultiPolygon:
+		m := &pmongo.MultiPolygon{}
+		err := coords.forEach(func(c *coordinates) error {
+			rings, err := c.rings()
+			m.Coordinates = append(m.Coordinates, &pmongo.Polygon{Coordinates: rings})
+			return err
+		})
+		return m, err
+	default:
+		return nil, fmt.Errorf("unknown GeoJSON type %q", typ)
+	}
+}
+
+// readCoordinates reads GeoJSON coordinates of nested arrays of any BSON numbers
+func readCoordinates(vr bsonrw.ValueReader) (*coordinates, error) {
+	if vr.Type() != bsontype.Array {
+		var c coordinates
+		if err := decodeNumber(vr, reflect.ValueOf(&c.number).Elem()); err != nil {
+			return nil, fmt.Errorf("GeoJSON coordinates must be arrays of numbers: %v", err)
+		}
+		return &c, nil
+	}
+	ar, err := vr.ReadArray()
+	if err != nil {
+		return nil, err
+	}
+	c := coordinates{array: []*coordinates{}}
+	for {
+		evr, err := ar.ReadValue()
+		if err == bsonrw.ErrEOA {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		e, err := readCoordinates(evr)
+		if err != nil {
+			return nil, err
+		}
+		c.array = append(c.array, e)
+	}
+	return &c, nil
+}
+
+// forEach calls f for each element of coordinates array
+func (c *coordinates) forEach(f func(c *coordinates) error) error {
+	if c.array == nil {
+		return fmt.Errorf("GeoJSON coordinates must be array, but got number %v", c.number)
+	}
+	for _, e := range c.array {
+		if err := f(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// position returns GeoJSON position of [longitude, latitude] array.
+// Altitude and other elements following latitude are not kept.
+func (c *coordinates) position() (*pmongo.Position, error) {
+	if len(c.array) < 2 || c.array[0].array != nil || c.array[1].array != nil {
+		return nil, fmt.Errorf("GeoJSON position must be array of at least 2 numbers")
+	}
+	return pmongo.NewPosition(c.array[0].number, c.array[1].number), nil
+}
+
+// positions returns array of GeoJSON positions
+func (c *coordinates) positions() ([]*pmongo.Position, error) {
+	var ps []*pmongo.Position
+	err := c.forEach(func(c *coordinates) error {
+		p, err := c.position()
+		ps = append(ps, p)
+		return err
+	})
+	return ps, err
+}
+
+// rings returns array of polygon linear rings
+func (c *coordinates) rings() ([]*pmongo.LinearRing, error) {
+	var rings []*pmongo.LinearRing
+	err := c.forEach(func(c *coordinates) error {
+		ps, err := c.positions()
+		rings = append(rings, &pmongo.LinearRing{Coordinates: ps})
+		return err
+	})
+	return rings, err
+}
@@ -0,0 +1,186 @@
+package codecs
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestGeoJSONCodec(t *testing.T) {
+	r := Register(bson.NewRegistryBuilder()).Build()
+
+	ring := &pmongo.LinearRing{Coordinates: []*pmongo.Position{
+		pmongo.NewPosition(0, 0), pmongo.NewPosition(3, 6), pmongo.NewPosition(6, 1), pmongo.NewPosition(0, 0),
+	}}
+	line, _ := pmongo.NewGeometry(&pmongo.LineString{Coordinates: ring.Coordinates[:2]})
+	point, _ := pmongo.NewGeometry(pmongo.NewPoint(1, 2))
+
+	t.Run("round trip", func(t *testing.T) {
+		in := &test.Place{
+			Location: pmongo.NewPoint(-73.9667, 40.78),
+			Zone:     &pmongo.Polygon{Coordinates: []*pmongo.LinearRing{ring}},
+			Area: &pmongo.Geometry{Geometry: &pmongo.Geometry_GeometryCollection{
+				GeometryCollection: &pmongo.GeometryCollection{Geometries: []*pmongo.Geometry{point, line}},
+			}},
+		}
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		var doc bson.M
+		if err = bson.Unmarshal(b, &doc); err != nil {
+			t.Errorf("bson.Unmarshal error = %v", err)
+			return
+		}
+		expect := bson.M{"type": "Point", "coordinates": bson.A{-73.9667, 40.78}}
+		if !reflect.DeepEqual(expect, doc["location"]) {
+			t.Errorf("failed: location=%v, expected=%v", doc["location"], expect)
+			return
+		}
+		if v := bson.Raw(b).Lookup("area", "geometries", "1", "type"); v.StringValue() != "LineString" {
+			t.Errorf("failed: area=%v", bson.Raw(b).Lookup("area"))
+			return
+		}
+
+		var out test.Place
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if !proto.Equal(in, &out) {
+			t.Errorf("failed: in=%v, out=%v", in, &out)
+			return
+		}
+	})
+
+	t.Run("decode existing document", func(t *testing.T) {
+		doc := bson.D{
+			// Integer coordinates, altitude and bbox are accepted
+			{Key: "location", Value: bson.D{{Key: "coordinates", Value: bson.A{int32(10), 20.5, 100}}, {Key: "type", Value: "Point"}}},
+			{Key: "area", Value: bson.D{
+				{Key: "type", Value: "MultiPolygon"},
+				{Key: "bbox", Value: bson.A{0, 0, 6, 6}},
+				{Key: "coordinates", Value: bson.A{bson.A{bson.A{
+					bson.A{0, 0}, bson.A{3, 6}, bson.A{6, 1}, bson.A{0, 0},
+				}}}},
+			}},
+		}
+		var out test.Place
+		if err := decodeDocument(r, doc, &out); err != nil {
+			t.Errorf("decodeDocument error = %v", err)
+			return
+		}
+		expect := &test.Place{
+			Location: pmongo.NewPoint(10, 20.5),
+			Area: &pmongo.Geometry{Geometry: &pmongo.Geometry_MultiPolygon{
+				MultiPolygon: &pmongo.MultiPolygon{Coordinates: []*pmongo.Polygon{{Coordinates: []*pmongo.LinearRing{ring}}}},
+			}},
+		}
+		if !proto.Equal(expect, &out) {
+			t.Errorf("failed: expected=%v, out=%v", expect, &out)
+			return
+		}
+	})
+
+	t.Run("top-level geometry", func(t *testing.T) {
+		for _, tt := range []struct {
+			in  proto.Message
+			out proto.Message
+		}{
+			{in: pmongo.NewPoint(1, 2), out: &pmongo.Point{}},
+			{in: &pmongo.Polygon{Coordinates: []*pmongo.LinearRing{ring}}, out: &pmongo.Polygon{}},
+			{in: point, out: &pmongo.Geometry{}},
+		} {
+			b, err := bson.MarshalWithRegistry(r, tt.in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			if err = bson.UnmarshalWithRegistry(r, b, tt.out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(tt.in, tt.out) {
+				t.Errorf("failed: in=%v, out=%v", tt.in, tt.out)
+				return
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		short := &pmongo.LinearRing{Coordinates: ring.Coordinates[:3]}
+		nilElement, _ := pmongo.NewGeometry(&pmongo.GeometryCollection{Geometries: []*pmongo.Geometry{nil}})
+		runFailCases(t, r, []failCase{
+			{
+				name:   "longitude out of range",
+				in:     &test.Place{Location: pmongo.NewPoint(181, 0)},
+				target: new(*pmongo.InvalidGeoJSONError),
+				reason: "Point: coordinates: longitude 181 is out of range",
+			},
+			{
+				name:   "unset position",
+				in:     &test.Place{Location: &pmongo.Point{}},
+				target: new(*pmongo.InvalidGeoJSONError),
+				reason: "Point: coordinates: position is not set",
+			},
+			{
+				name:   "short ring",
+				in:     &test.Place{Zone: &pmongo.Polygon{Coordinates: []*pmongo.LinearRing{short}}},
+				target: new(*pmongo.InvalidGeoJSONError),
+				reason: "Polygon: coordinates.0: linear ring must have at least 4 positions",
+			},
+			{
+				name:   "unset geometry",
+				in:     &test.Place{Area: &pmongo.Geometry{}},
+				target: new(*pmongo.InvalidGeoJSONError),
+				reason: "invalid GeoJSON geometry: geometry is not set",
+			},
+			{
+				name:   "nil geometry in collection",
+				in:     &test.Place{Area: nilElement},
+				target: new(*pmongo.InvalidGeoJSONError),
+				reason: "GeometryCollection: geometries.0: geometry is not set",
+			},
+			{
+				name:   "decode latitude out of range",
+				doc:    bson.D{{Key: "location", Value: bson.D{{Key: "type", Value: "Point"}, {Key: "coordinates", Value: bson.A{0, 91}}}}},
+				out:    &test.Place{},
+				target: new(*pmongo.InvalidGeoJSONError),
+				reason: "Point: coordinates: latitude 91 is out of range",
+			},
+			{
+				name:   "decode other type",
+				doc:    bson.D{{Key: "location", Value: bson.D{{Key: "type", Value: "LineString"}, {Key: "coordinates", Value: bson.A{bson.A{0, 1}, bson.A{1, 0}}}}}},
+				out:    &test.Place{},
+				reason: "cannot decode GeoJSON LineString into pmongo.Point",
+			},
+			{
+				name:   "decode string coordinates",
+				doc:    bson.D{{Key: "location", Value: bson.D{{Key: "type", Value: "Point"}, {Key: "coordinates", Value: bson.A{"0", "1"}}}}},
+				out:    &test.Place{},
+				reason: "GeoJSON coordinates must be arrays of numbers",
+			},
+			{
+				name: "decode open ring",
+				doc: bson.D{{Key: "zone", Value: bson.D{{Key: "type", Value: "Polygon"}, {Key: "coordinates", Value: bson.A{bson.A{
+					bson.A{0, 0}, bson.A{3, 6}, bson.A{6, 1}, bson.A{1, 1},
+				}}}}}},
+				out:    &test.Place{},
+				target: new(*pmongo.InvalidGeoJSONError),
+				reason: "Polygon: coordinates.0: linear ring is not closed",
+			},
+			{
+				name:   "decode unknown type",
+				doc:    bson.D{{Key: "area", Value: bson.D{{Key: "type", Value: "Circle"}, {Key: "coordinates", Value: bson.A{0, 0}}}}},
+				out:    &test.Place{},
+				reason: "unknown GeoJSON type \"Circle\"",
+			},
+		})
+	})
+}
@@ -1,6 +1,9 @@
 module github.com/amsokol/mongo-go-driver-protobuf
 
+go 1.23
+
 require (
-	github.com/golang/protobuf v1.2.1-0.20190205222052-c823c79ea157
+	github.com/golang/protobuf v1.5.4
 	go.mongodb.org/mongo-driver v1.0.0-rc1
+	google.golang.org/protobuf v1.36.11
 )
@@ -0,0 +1,108 @@
+package codecs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"github.com/amsokol/mongo-go-driver-protobuf/test/legacy"
+)
+
+func TestLegacyMessage(t *testing.T) {
+	rb := bson.NewRegistryBuilder()
+	r := Register(rb).Build()
+
+	ts, err := ptypes.TimestampProto(time.Date(2019, 2, 1, 10, 20, 30, 0, time.UTC))
+	if err != nil {
+		t.Errorf("ptypes.TimestampProto error = %v", err)
+		return
+	}
+
+	in := &legacy.Data{
+		StringValue: &wrappers.StringValue{Value: "qwerty"},
+		Timestamp:   ts,
+		Id:          pmongo.NewObjectId(primitive.NewObjectID()),
+		SnakeCase:   "snake",
+		Children: []*legacy.Data{
+			{StringValue: &wrappers.StringValue{Value: "child"}},
+		},
+	}
+
+	b, err := bson.MarshalWithRegistry(r, in)
+	if err != nil {
+		t.Errorf("bson.MarshalWithRegistry error = %v", err)
+		return
+	}
+
+	elems, err := bson.Raw(b).Elements()
+	if err != nil {
+		t.Errorf("bson.Raw.Elements error = %v", err)
+		return
+	}
+	keys := make([]string, 0, len(elems))
+	for _, e := range elems {
+		keys = append(keys, e.Key())
+	}
+	expected := []string{"stringValue", "timestamp", "id", "snake_case", "children"}
+	if !reflect.DeepEqual(expected, keys) {
+		t.Errorf("failed: expected keys=%v, keys=%v", expected, keys)
+		return
+	}
+
+	var out legacy.Data
+	if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+		t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+		return
+	}
+	if !proto.Equal(protoadapt.MessageV2Of(in), protoadapt.MessageV2Of(&out)) {
+		t.Errorf("failed: in=%v, out=%v", in, &out)
+		return
+	}
+}
+
+func TestLegacyStructCodec(t *testing.T) {
+	rb := bson.NewRegistryBuilder()
+	r := RegisterWithOptions(rb, NewOptions(WithTypes(TypeAll&^TypeMessage))).Build()
+
+	in := &legacy.Data{
+		StringValue: &wrappers.StringValue{Value: "qwerty"},
+		SnakeCase:   "snake",
+	}
+	in.XXX_unrecognized = []byte{1, 2, 3}
+
+	b, err := bson.MarshalWithRegistry(r, in)
+	if err != nil {
+		t.Errorf("bson.MarshalWithRegistry error = %v", err)
+		return
+	}
+	elems, err := bson.Raw(b).Elements()
+	if err != nil {
+		t.Errorf("bson.Raw.Elements error = %v", err)
+		return
+	}
+	for _, e := range elems {
+		if strings.HasPrefix(e.Key(), "xxx_") {
+			t.Errorf("failed: protobuf runtime internal %q is written to %v", e.Key(), bson.Raw(b))
+			return
+		}
+	}
+
+	var out legacy.Data
+	if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+		t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+		return
+	}
+	if out.GetStringValue().GetValue() != "qwerty" || out.SnakeCase != "snake" || out.XXX_unrecognized != nil {
+		t.Errorf("failed: out=%v", &out)
+		return
+	}
+}
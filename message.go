@@ -0,0 +1,676 @@
+package codecs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// Protobuf message interface type.
+	// Messages generated for both APIv2 and legacy API implement it.
+	protoMessageType = reflect.TypeOf((*protoadapt.MessageV1)(nil)).Elem()
+
+	// Go types of proto scalar values
+	boolType    = reflect.TypeOf(false)
+	int32Type   = reflect.TypeOf(int32(0))
+	uint32Type  = reflect.TypeOf(uint32(0))
+	uint64Type  = reflect.TypeOf(uint64(0))
+	float32Type = reflect.TypeOf(float32(0))
+	float64Type = reflect.TypeOf(float64(0))
+	stringType  = reflect.TypeOf("")
+	bytesType   = reflect.TypeOf([]byte(nil))
+)
+
+// messageCodec is codec for any Protobuf message registered as hook for Protobuf message interface.
+// It walks message fields using protoreflect instead of Go struct fields:
+//   - proto field names (or JSON names) are used as BSON keys,
+//     "bson" struct tag (e.g. added by protoc-gen-gotag) overrides the key,
+//   - protobuf runtime internals are skipped,
+//   - fields having default values are omitted the same way as proto3 JSON mapping does,
+//   - enum fields are stored as numbers or names,
+//   - member of oneof is stored under its own key in parent document,
+//   - unknown fields are dropped or kept to be restored on decode.
+//
+// Messages having own registered codecs (wrappers, Timestamp, ObjectId, etc.) are passed to them.
+type messageCodec struct {
+	keyNaming     KeyNaming
+	nilPolicy     NilPolicy
+	enums         EnumRepresentation
+	unknownEnums  UnknownEnumPolicy
+	uint64s       Uint64Storage
+	unknownFields UnknownFieldsPolicy
+
+	cache map[messageKey]*messageDescription
+	l     sync.RWMutex
+}
+
+// messageKey is key of message description cache.
+// Message descriptor is part of key as all dynamic messages (dynamicpb) have the same Go type.
+type messageKey struct {
+	t    reflect.Type
+	desc protoreflect.MessageDescriptor
+}
+
+// messageDescription is description of message fields
+type messageDescription struct {
+	fl []messageField
+	fm map[string]messageField
+}
+
+// messageField is description of message field
+type messageField struct {
+	fd  protoreflect.FieldDescriptor
+	key string
+}
+
+// newMessageCodec creates codec for Protobuf messages
+func newMessageCodec(opts *Options) *messageCodec {
+	return &messageCodec{
+		keyNaming:     opts.KeyNaming,
+		nilPolicy:     opts.NilPolicy,
+		enums:         opts.EnumRepresentation,
+		unknownEnums:  opts.UnknownEnumPolicy,
+		uint64s:       opts.Uint64Storage,
+		unknownFields: opts.UnknownFieldsPolicy,
+		cache:         make(map[messageKey]*messageDescription),
+	}
+}
+
+// EncodeValue encodes Protobuf message value to BSON value
+func (e *messageCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() != reflect.Ptr {
+		return bsoncodec.ValueEncoderError{Name: "messageCodec.EncodeValue", Kinds: []reflect.Kind{reflect.Ptr}, Received: val}
+	}
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+	enc, err := ectx.LookupEncoder(val.Type().Elem())
+	if err != nil {
+		return err
+	}
+	if !isStructCodec(enc) {
+		return enc.EncodeValue(ectx, vw, val.Elem())
+	}
+	return e.encodeMessage(ectx, vw, val)
+}
+
+// encodeMessage encodes Protobuf message pointer to BSON document
+func (e *messageCodec) encodeMessage(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	m := protoadapt.MessageV2Of(val.Interface().(protoadapt.MessageV1)).ProtoReflect()
+	md, err := e.describeMessage(val.Type().Elem(), m.Descriptor())
+	if err != nil {
+		return err
+	}
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	for _, f := range md.fl {
+		if !m.Has(f.fd) {
+			if e.nilPolicy != NilNull || !f.fd.HasPresence() || isOneofField(f.fd) {
+				continue
+			}
+			fvw, err := dw.WriteDocumentElement(f.key)
+			if err != nil {
+				return err
+			}
+			if err = fvw.WriteNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		v := m.Get(f.fd)
+		if isZeroMessage(ectx, f.fd, v) {
+			continue
+		}
+		fvw, err := dw.WriteDocumentElement(f.key)
+		if err != nil {
+			return err
+		}
+		if err = e.encodeField(ectx, fvw, f.fd, v); err != nil {
+			return withPathElement(err, f.key)
+		}
+	}
+	if e.unknownFields == UnknownFieldsKeep {
+		if err = encodeUnknown(dw, m, md); err != nil {
+			return err
+		}
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// encodeField writes message field value (singular, repeated or map) as BSON value
+func (e *messageCodec) encodeField(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	switch {
+	case fd.IsList():
+		l := v.List()
+		aw, err := vw.WriteArray()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < l.Len(); i++ {
+			evw, err := aw.WriteArrayElement()
+			if err != nil {
+				return err
+			}
+			if err = e.encodeSingular(ectx, evw, fd, l.Get(i)); err != nil {
+				return withPathElement(err, strconv.Itoa(i))
+			}
+		}
+		return aw.WriteArrayEnd()
+	case fd.IsMap():
+		mp := v.Map()
+		dw, err := vw.WriteDocument()
+		if err != nil {
+			return err
+		}
+		// Entries are written in sorted order of keys, so the same map is always the same document
+		keys := make([]protoreflect.MapKey, 0, mp.Len())
+		mp.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		sortMapKeys(fd.MapKey(), keys)
+		for _, k := range keys {
+			// Keys are stringified the same way as proto3 JSON mapping does, e.g. "1" or "true"
+			key := k.String()
+			evw, err := dw.WriteDocumentElement(key)
+			if err != nil {
+				return err
+			}
+			if err = e.encodeSingular(ectx, evw, fd.MapValue(), mp.Get(k)); err != nil {
+				return withPathElement(err, key)
+			}
+		}
+		return dw.WriteDocumentEnd()
+	default:
+		return e.encodeSingular(ectx, vw, fd, v)
+	}
+}
+
+// encodeSingular writes single value of message field as BSON value.
+// Messages are encoded by codecs registered for their Go types.
+func (e *messageCodec) encodeSingular(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return vw.WriteBoolean(v.Bool())
+	case protoreflect.EnumKind:
+		return encodeEnum(vw, fd.Enum(), v.Enum(), e.enums, e.unknownEnums)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return vw.WriteInt32(int32(v.Int()))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return vw.WriteInt64(v.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		// BSON has no unsigned integers
+		return vw.WriteInt64(int64(v.Uint()))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if err := encodeUint64(vw, v.Uint(), e.uint64s); err != nil {
+			return fmt.Errorf("field %s: %v", fd.FullName(), err)
+		}
+		return nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return vw.WriteDouble(v.Float())
+	case protoreflect.StringKind:
+		return vw.WriteString(v.String())
+	case protoreflect.BytesKind:
+		return vw.WriteBinary(v.Bytes())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := reflect.ValueOf(protoadapt.MessageV1Of(v.Message().Interface()))
+		enc, err := ectx.LookupEncoder(msg.Type())
+		if err != nil {
+			return err
+		}
+		return enc.EncodeValue(ectx, vw, msg)
+	default:
+		return fmt.Errorf("cannot encode field %s of kind %v", fd.FullName(), fd.Kind())
+	}
+}
+
+// DecodeValue decodes BSON value to Protobuf message value
+func (e *messageCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "messageCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+	switch val.Kind() {
+	case reflect.Ptr:
+		if isNullValue(vr.Type()) {
+			val.Set(reflect.Zero(val.Type()))
+			return readNullValue(vr)
+		}
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		dec, err := ectx.LookupDecoder(val.Type().Elem())
+		if err != nil {
+			return err
+		}
+		if dec != e {
+			return dec.DecodeValue(ectx, vr, val.Elem())
+		}
+		return e.decodeMessage(ectx, vr, val)
+	case reflect.Struct:
+		return e.decodeMessage(ectx, vr, val.Addr())
+	default:
+		return bsoncodec.ValueDecoderError{Name: "messageCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+}
+
+// decodeMessage decodes BSON document to Protobuf message pointer
+func (e *messageCodec) decodeMessage(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	switch vr.Type() {
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	default:
+		return fmt.Errorf("cannot decode %v into a %s", vr.Type(), val.Type().Elem())
+	}
+	m := protoadapt.MessageV2Of(val.Interface().(protoadapt.MessageV1)).ProtoReflect()
+	md, err := e.describeMessage(val.Type().Elem(), m.Descriptor())
+	if err != nil {
+		return err
+	}
+	dr, err := vr.ReadDocument()
+	if err != nil {
+		return err
+	}
+	// Keys of oneof members decoded
+	oneofs := make(map[protoreflect.OneofDescriptor]string)
+	var unknown unknownDecoder
+	for {
+		key, evr, err := dr.ReadElement()
+		if err == bsonrw.ErrEOD {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		f, ok := md.fm[key]
+		if !ok {
+			if e.unknownFields == UnknownFieldsKeep {
+				if err = unknown.decodeElement(key, evr); err != nil {
+					return err
+				}
+				continue
+			}
+			// Skip fields unknown to this version of message
+			if err = evr.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if isNullValue(evr.Type()) && (f.fd.IsList() || f.fd.IsMap() || !isValueField(ectx, f.fd)) {
+			// Null and undefined values mean unset field except for Value keeping null
+			m.Clear(f.fd)
+			if err = readNullValue(evr); err != nil {
+				return err
+			}
+			continue
+		}
+		if od := f.fd.ContainingOneof(); isOneofField(f.fd) {
+			// Oneof member is selected by key, only one member of oneof can be present
+			if prev, ok := oneofs[od]; ok {
+				return fmt.Errorf("oneof %s has more than one member: %q and %q", od.FullName(), prev, key)
+			}
+			oneofs[od] = key
+		}
+		if err = e.decodeField(ectx, evr, m, f.fd); err != nil {
+			return withPathElement(err, key)
+		}
+	}
+	return unknown.setUnknown(m)
+}
+
+// decodeField reads message field value (singular, repeated or map) from BSON value
+func (e *messageCodec) decodeField(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	switch {
+	case fd.IsList():
+		if vr.Type() != bsontype.Array {
+			return fmt.Errorf("cannot decode %v into repeated field %s", vr.Type(), fd.FullName())
+		}
+		ar, err := vr.ReadArray()
+		if err != nil {
+			return err
+		}
+		lv := m.NewField(fd)
+		l := lv.List()
+		for i := 0; ; i++ {
+			evr, err := ar.ReadValue()
+			if err == bsonrw.ErrEOA {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			var ev protoreflect.Value
+			if fd.Message() != nil {
+				ev = l.NewElement()
+			}
+			if ev, err = e.decodeSingular(ectx, evr, fd, ev); err != nil {
+				return withPathElement(err, strconv.Itoa(i))
+			}
+			if !ev.IsValid() {
+				return fmt.Errorf("cannot decode null into element %d of repeated field %s", i, fd.FullName())
+			}
+			l.Append(ev)
+		}
+		m.Set(fd, lv)
+		return nil
+	case fd.IsMap():
+		switch vr.Type() {
+		case bsontype.Type(0), bsontype.EmbeddedDocument:
+		default:
+			return fmt.Errorf("cannot decode %v into map field %s", vr.Type(), fd.FullName())
+		}
+		dr, err := vr.ReadDocument()
+		if err != nil {
+			return err
+		}
+		mv := m.NewField(fd)
+		mp := mv.Map()
+		for {
+			key, evr, err := dr.ReadElement()
+			if err == bsonrw.ErrEOD {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			mk, err := parseMapKey(fd.MapKey(), key)
+			if err != nil {
+				return err
+			}
+			var ev protoreflect.Value
+			if fd.MapValue().Message() != nil {
+				ev = mp.NewValue()
+			}
+			if ev, err = e.decodeSingular(ectx, evr, fd.MapValue(), ev); err != nil {
+				return withPathElement(err, key)
+			}
+			if !ev.IsValid() {
+				return fmt.Errorf("cannot decode null into value %q of map field %s", key, fd.FullName())
+			}
+			mp.Set(mk, ev)
+		}
+		m.Set(fd, mv)
+		return nil
+	case fd.Message() != nil:
+		v, err := e.decodeSingular(ectx, vr, fd, m.NewField(fd))
+		if err != nil {
+			return err
+		}
+		if !v.IsValid() {
+			// Codec of message decoded nil pointer
+			m.Clear(fd)
+			return nil
+		}
+		m.Set(fd, v)
+		return nil
+	default:
+		v, err := e.decodeSingular(ectx, vr, fd, protoreflect.Value{})
+		if err != nil {
+			return err
+		}
+		m.Set(fd, v)
+		return nil
+	}
+}
+
+// decodeSingular reads single value of message field from BSON value.
+// Message value mv is allocated by caller and decoded by codec registered for pointer to its Go type,
+// invalid value is returned if codec sets nil pointer.
+// Numbers are decoded from any BSON numeric type, other scalars are decoded by codecs
+// registered for matching Go types.
+func (e *messageCodec) decodeSingular(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, fd protoreflect.FieldDescriptor, mv protoreflect.Value) (protoreflect.Value, error) {
+	var t reflect.Type
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		t = boolType
+	case protoreflect.EnumKind:
+		n, err := decodeEnum(vr, fd.Enum(), e.unknownEnums)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfEnum(n), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		t = int32Type
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		t = int64Type
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		t = uint32Type
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		t = uint64Type
+	case protoreflect.FloatKind:
+		t = float32Type
+	case protoreflect.DoubleKind:
+		t = float64Type
+	case protoreflect.StringKind:
+		t = stringType
+	case protoreflect.BytesKind:
+		t = bytesType
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		ptr := reflect.ValueOf(protoadapt.MessageV1Of(mv.Message().Interface()))
+		if isValueField(ectx, fd) {
+			// Value is decoded in place to keep null as NullValue instead of nil pointer
+			if err := valueCodecRef.DecodeValue(ectx, vr, ptr.Elem()); err != nil {
+				return protoreflect.Value{}, err
+			}
+			return mv, nil
+		}
+		msg := reflect.New(ptr.Type()).Elem()
+		msg.Set(ptr)
+		dec, err := ectx.LookupDecoder(msg.Type())
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		if err = dec.DecodeValue(ectx, vr, msg); err != nil {
+			return protoreflect.Value{}, err
+		}
+		if msg.IsNil() {
+			return protoreflect.Value{}, nil
+		}
+		return protoreflect.ValueOfMessage(protoadapt.MessageV2Of(msg.Interface().(protoadapt.MessageV1)).ProtoReflect()), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("cannot decode field %s of kind %v", fd.FullName(), fd.Kind())
+	}
+	v := reflect.New(t).Elem()
+	if isNumberKind(t.Kind()) {
+		if err := decodeNumber(vr, v); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOf(v.Interface()), nil
+	}
+	dec, err := ectx.LookupDecoder(t)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	if err = dec.DecodeValue(ectx, vr, v); err != nil {
+		return protoreflect.Value{}, err
+	}
+	return protoreflect.ValueOf(v.Interface()), nil
+}
+
+// sortMapKeys sorts keys of map field fd: numbers in numeric order, strings in lexical order
+// and false before true
+func sortMapKeys(fd protoreflect.FieldDescriptor, keys []protoreflect.MapKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		switch fd.Kind() {
+		case protoreflect.BoolKind:
+			return !keys[i].Bool() && keys[j].Bool()
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+			protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			return keys[i].Int() < keys[j].Int()
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return keys[i].Uint() < keys[j].Uint()
+		default:
+			return keys[i].String() < keys[j].String()
+		}
+	})
+}
+
+// parseMapKey parses BSON document key to map key of field fd.
+// Integer and bool keys are parsed from strings the same way as proto3 JSON mapping does.
+func parseMapKey(fd protoreflect.FieldDescriptor, key string) (protoreflect.MapKey, error) {
+	var v protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(key)
+	case protoreflect.BoolKind:
+		switch key {
+		case "true":
+			v = protoreflect.ValueOfBool(true)
+		case "false":
+			v = protoreflect.ValueOfBool(false)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if n, err := strconv.ParseInt(key, 10, 32); err == nil {
+			v = protoreflect.ValueOfInt32(int32(n))
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if n, err := strconv.ParseInt(key, 10, 64); err == nil {
+			v = protoreflect.ValueOfInt64(n)
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if n, err := strconv.ParseUint(key, 10, 32); err == nil {
+			v = protoreflect.ValueOfUint32(uint32(n))
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if n, err := strconv.ParseUint(key, 10, 64); err == nil {
+			v = protoreflect.ValueOfUint64(n)
+		}
+	}
+	if !v.IsValid() {
+		return protoreflect.MapKey{}, fmt.Errorf("cannot parse key %q as %v map key", key, fd.Kind())
+	}
+	return v.MapKey(), nil
+}
+
+// describeMessage returns cached description of fields of message having Go struct type t and descriptor desc
+func (e *messageCodec) describeMessage(t reflect.Type, desc protoreflect.MessageDescriptor) (*messageDescription, error) {
+	key := messageKey{t: t, desc: desc}
+	e.l.RLock()
+	md, ok := e.cache[key]
+	e.l.RUnlock()
+	if ok {
+		return md, nil
+	}
+
+	// "bson" struct tags and Go field names by proto field names
+	tags := make(map[string]string)
+	goNames := make(map[string]string)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		for _, opt := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+			if !strings.HasPrefix(opt, "name=") {
+				continue
+			}
+			name := strings.TrimPrefix(opt, "name=")
+			goNames[name] = sf.Name
+			if tag, ok := sf.Tag.Lookup("bson"); ok {
+				tags[name] = tag
+			}
+		}
+	}
+
+	fields := desc.Fields()
+	md = &messageDescription{
+		fl: make([]messageField, 0, fields.Len()),
+		fm: make(map[string]messageField, fields.Len()),
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		f := messageField{fd: fd, key: string(fd.Name())}
+		// Primary key is kept verbatim whatever key naming is
+		if fd.Name() != insertedIDKey {
+			switch e.keyNaming {
+			case KeyJSONName:
+				f.key = fd.JSONName()
+			case KeyLowercase:
+				if goName, ok := goNames[string(fd.Name())]; ok {
+					f.key = strings.ToLower(goName)
+				}
+			}
+		}
+		if tag, ok := tags[string(fd.Name())]; ok {
+			if tag == "-" {
+				continue
+			}
+			if name := strings.Split(tag, ",")[0]; name != "" {
+				f.key = name
+			}
+		}
+		if _, ok := md.fm[f.key]; ok {
+			return nil, fmt.Errorf("(message %s) duplicated key %s", desc.FullName(), f.key)
+		}
+		md.fl = append(md.fl, f)
+		md.fm[f.key] = f
+	}
+
+	e.l.Lock()
+	e.cache[key] = md
+	e.l.Unlock()
+	return md, nil
+}
+
+// fieldPathError is error reporting path of message field it occurred in
+type fieldPathError interface {
+	error
+	addPathElement(elem string)
+}
+
+// withPathElement adds field name or index to path of error reporting field path
+func withPathElement(err error, elem string) error {
+	var pe fieldPathError
+	if errors.As(err, &pe) {
+		pe.addPathElement(elem)
+	}
+	return err
+}
+
+// prependPathElement adds field name or index to the beginning of dot separated field path
+func prependPathElement(path, elem string) string {
+	if path == "" {
+		return elem
+	}
+	return elem + "." + path
+}
+
+// isZeroMessage returns true if singular message field value is reported as zero value
+// to be omitted by its codec, e.g. empty ObjectId encoded with EmptyObjectIDOmit policy
+func isZeroMessage(ectx bsoncodec.EncodeContext, fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return false
+	}
+	msg := protoadapt.MessageV1Of(v.Message().Interface())
+	enc, err := ectx.LookupEncoder(reflect.TypeOf(msg))
+	if err != nil {
+		return false
+	}
+	z, ok := enc.(bsoncodec.CodecZeroer)
+	return ok && z.IsTypeZero(msg)
+}
+
+// isOneofField returns true if field is member of oneof declared in proto file.
+// Synthetic oneofs of proto3 optional fields are not counted.
+func isOneofField(fd protoreflect.FieldDescriptor) bool {
+	od := fd.ContainingOneof()
+	return od != nil && !od.IsSynthetic()
+}
+
+// isValueField returns true if field or its elements are google.protobuf.Value decoded by Value codec,
+// which decodes BSON null as NullValue
+func isValueField(ectx bsoncodec.DecodeContext, fd protoreflect.FieldDescriptor) bool {
+	if fd.Message() == nil || fd.Message().FullName() != "google.protobuf.Value" {
+		return false
+	}
+	dec, err := ectx.LookupDecoder(valueType)
+	return err == nil && dec == valueCodecRef
+}
@@ -0,0 +1,275 @@
+package codecs
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestMessageCodec(t *testing.T) {
+	in := &test.Data{
+		Int32Value: &wrapperspb.Int32Value{Value: 0},
+		SnakeCase:  "qwerty",
+		Children: []*test.Data{
+			{StringValue: &wrapperspb.StringValue{Value: "child"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		keyNaming KeyNaming
+		keys      []string
+	}{
+		{name: "proto names", keyNaming: KeyProtoName, keys: []string{"int32Value", "snake_case", "children"}},
+		{name: "JSON names", keyNaming: KeyJSONName, keys: []string{"int32Value", "snakeCase", "children"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := bson.NewRegistryBuilder()
+			r := RegisterWithOptions(rb, NewOptions(WithKeyNaming(tt.keyNaming))).Build()
+
+			b, err := bson.MarshalWithRegistry(r, in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+
+			elems, err := bson.Raw(b).Elements()
+			if err != nil {
+				t.Errorf("bson.Raw.Elements error = %v", err)
+				return
+			}
+			keys := make([]string, 0, len(elems))
+			for _, e := range elems {
+				keys = append(keys, e.Key())
+			}
+			if !reflect.DeepEqual(tt.keys, keys) {
+				t.Errorf("failed: expected keys=%v, keys=%v", tt.keys, keys)
+				return
+			}
+
+			var out test.Data
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(in, &out) {
+				t.Errorf("failed: in=%v, out=%v", in, &out)
+				return
+			}
+		})
+	}
+
+	t.Run("unknown keys are skipped", func(t *testing.T) {
+		rb := bson.NewRegistryBuilder()
+		r := Register(rb).Build()
+
+		b, err := bson.Marshal(bson.D{{Key: "snake_case", Value: "qwerty"}, {Key: "removed", Value: 1}})
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if out.SnakeCase != "qwerty" {
+			t.Errorf("failed: out=%v", &out)
+			return
+		}
+	})
+
+	t.Run("oneof", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		for _, in := range []*test.Data{
+			{Kind: &test.Data_Name{Name: "qwerty"}},
+			{Kind: &test.Data_Number{Number: 0}},
+			{Kind: &test.Data_Time{Time: &timestamppb.Timestamp{Seconds: 1}}},
+			{Kind: &test.Data_Child{Child: &test.Data{Kind: &test.Data_Name{Name: "child"}}}},
+		} {
+			b, err := bson.MarshalWithRegistry(r, in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			elems, err := bson.Raw(b).Elements()
+			if err != nil {
+				t.Errorf("bson.Raw.Elements error = %v", err)
+				return
+			}
+			if len(elems) != 1 {
+				t.Errorf("failed: single oneof member key expected in %v", bson.Raw(b))
+				return
+			}
+
+			var out test.Data
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(in, &out) {
+				t.Errorf("failed: in=%v, out=%v", in, &out)
+				return
+			}
+		}
+	})
+
+	t.Run("oneof having more than one member", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		b, err := bson.Marshal(bson.D{{Key: "name", Value: "qwerty"}, {Key: "number", Value: int64(1)}})
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err == nil {
+			t.Errorf("bson.UnmarshalWithRegistry error expected, out=%v", &out)
+			return
+		}
+	})
+
+	t.Run("map keys", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		in := &test.Data{
+			Labels: map[int32]string{-1: "minus", 10: "ten"},
+			Flags:  map[bool]*test.Data{true: {SnakeCase: "yes"}, false: {}},
+		}
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if v, err := bson.Raw(b).LookupErr("labels", "-1"); err != nil || v.StringValue() != "minus" {
+			t.Errorf("failed: labels.-1=%v, error=%v", v, err)
+			return
+		}
+		if v, err := bson.Raw(b).LookupErr("flags", "true", "snake_case"); err != nil || v.StringValue() != "yes" {
+			t.Errorf("failed: flags.true.snake_case=%v, error=%v", v, err)
+			return
+		}
+
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if !proto.Equal(in, &out) {
+			t.Errorf("failed: in=%v, out=%v", in, &out)
+			return
+		}
+
+		// Entries are written in sorted order of keys on every encode
+		many := &test.Data{Labels: map[int32]string{}, Attributes: map[string]*structpb.Value{}}
+		for i := int32(20); i > -20; i-- {
+			many.Labels[i] = "x"
+			many.Attributes[strconv.Itoa(int(i))] = structpb.NewBoolValue(true)
+		}
+		first, err := bson.MarshalWithRegistry(r, many)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if keys, _ := bson.Raw(first).Lookup("labels").Document().Elements(); keys[0].Key() != "-19" || keys[1].Key() != "-18" {
+			t.Errorf("failed: labels=%v are not sorted", bson.Raw(first).Lookup("labels"))
+			return
+		}
+		for i := 0; i < 10; i++ {
+			b, err := bson.MarshalWithRegistry(r, many)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			if !bytes.Equal(first, b) {
+				t.Errorf("failed: encoded documents differ: %v and %v", bson.Raw(first), bson.Raw(b))
+				return
+			}
+		}
+
+		for _, doc := range []bson.D{
+			{{Key: "labels", Value: bson.D{{Key: "1.5", Value: "x"}}}},
+			{{Key: "labels", Value: bson.D{{Key: "4294967296", Value: "x"}}}},
+			{{Key: "flags", Value: bson.D{{Key: "1", Value: bson.D{}}}}},
+		} {
+			b, err := bson.Marshal(doc)
+			if err != nil {
+				t.Errorf("bson.Marshal error = %v", err)
+				return
+			}
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err == nil {
+				t.Errorf("bson.UnmarshalWithRegistry error expected for %v", doc)
+				return
+			}
+		}
+	})
+	t.Run("null Value", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		null := structpb.NewNullValue()
+		in := &test.Data{
+			Value:      null,
+			Values:     []*structpb.Value{structpb.NewStringValue("qwerty"), null},
+			Attributes: map[string]*structpb.Value{"null": null, "number": structpb.NewNumberValue(1)},
+		}
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if v, err := bson.Raw(b).LookupErr("value"); err != nil || v.Type != bson.TypeNull {
+			t.Errorf("failed: value=%v, error=%v", v, err)
+			return
+		}
+
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if !proto.Equal(in, &out) {
+			t.Errorf("failed: in=%v, out=%v", in, &out)
+			return
+		}
+	})
+	t.Run("dynamic messages", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		for _, m := range []proto.Message{
+			&pmongo.Regex{Pattern: "^abc", Options: "i"},
+			&test.Record{Name: "qwerty"},
+		} {
+			// All dynamic messages have the same Go type
+			in := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+			proto.Merge(in, m)
+			b, err := bson.MarshalWithRegistry(r, in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			out := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+			if err = bson.UnmarshalWithRegistry(r, b, out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(in, out) {
+				t.Errorf("failed: in=%v, out=%v", in, out)
+				return
+			}
+		}
+	})
+}
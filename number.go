@@ -0,0 +1,192 @@
+package codecs
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NumberError is error of BSON number which can't be represented by Go type of Protobuf value
+type NumberError struct {
+	// Path is dot separated path of message field having the number, e.g. "children.0.int32Value".
+	// It is empty if number is not decoded as part of Protobuf message.
+	Path string
+	// Value is BSON number as string
+	Value string
+	// Kind is Go kind of Protobuf value
+	Kind reflect.Kind
+	// Reason describes why number can't be represented, e.g. "value overflows"
+	Reason string
+}
+
+// Error returns error message
+func (e *NumberError) Error() string {
+	msg := fmt.Sprintf("cannot decode number %s into %v: %s", e.Value, e.Kind, e.Reason)
+	if e.Path != "" {
+		msg = fmt.Sprintf("field %s: %s", e.Path, msg)
+	}
+	return msg
+}
+
+// addPathElement adds field name or index to the beginning of path
+func (e *NumberError) addPathElement(elem string) {
+	e.Path = prependPathElement(e.Path, elem)
+}
+
+// Ranges of Go integer kinds
+var integerRanges = map[reflect.Kind][2]*big.Int{
+	reflect.Int32:  {big.NewInt(math.MinInt32), big.NewInt(math.MaxInt32)},
+	reflect.Int64:  {big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64)},
+	reflect.Uint32: {big.NewInt(0), big.NewInt(math.MaxUint32)},
+	reflect.Uint64: {big.NewInt(0), new(big.Int).SetUint64(math.MaxUint64)},
+}
+
+// isNumberKind returns true if Go kind is decoded by decodeNumber
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int32, reflect.Int64, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// decodeNumber reads any BSON number (int32, int64, double or Decimal128) to settable value
+// of Go kind Int32, Int64, Uint32, Uint64, Float32 or Float64.
+// Integers must be whole numbers in range of Go type. Floats must not overflow Go type,
+// whole numbers must be represented exactly, fractions are rounded to the nearest float.
+func decodeNumber(vr bsonrw.ValueReader, val reflect.Value) error {
+	r, f, s, err := readNumber(vr, val.Kind())
+	if err != nil {
+		return err
+	}
+	switch val.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if r == nil || r.Sign() == 0 {
+			// NaN, infinities and zeros (to keep negative zero) are kept as is
+			val.SetFloat(f)
+			return nil
+		}
+		bf := new(big.Float).SetRat(r)
+		var acc big.Accuracy
+		if val.Kind() == reflect.Float32 {
+			var f32 float32
+			f32, acc = bf.Float32()
+			f = float64(f32)
+		} else {
+			f, acc = bf.Float64()
+		}
+		if math.IsInf(f, 0) {
+			return &NumberError{Value: s, Kind: val.Kind(), Reason: "value overflows"}
+		}
+		if acc != big.Exact && r.IsInt() {
+			return &NumberError{Value: s, Kind: val.Kind(), Reason: "value loses precision"}
+		}
+		val.SetFloat(f)
+		return nil
+	default:
+		if r == nil {
+			return &NumberError{Value: s, Kind: val.Kind(), Reason: "value is not finite"}
+		}
+		if !r.IsInt() {
+			return &NumberError{Value: s, Kind: val.Kind(), Reason: "value has fractional part"}
+		}
+		n, rng := r.Num(), integerRanges[val.Kind()]
+		if n.Cmp(rng[0]) < 0 || n.Cmp(rng[1]) > 0 {
+			return &NumberError{Value: s, Kind: val.Kind(), Reason: "value overflows"}
+		}
+		if val.Kind() == reflect.Uint32 || val.Kind() == reflect.Uint64 {
+			val.SetUint(n.Uint64())
+		} else {
+			val.SetInt(n.Int64())
+		}
+		return nil
+	}
+}
+
+// encodeUint64 writes uint64 value in BSON representation defined by storage
+func encodeUint64(vw bsonrw.ValueWriter, v uint64, storage Uint64Storage) error {
+	switch storage {
+	case Uint64Decimal128:
+		d, err := primitive.ParseDecimal128(strconv.FormatUint(v, 10))
+		if err != nil {
+			return err
+		}
+		return vw.WriteDecimal128(d)
+	case Uint64String:
+		return vw.WriteString(strconv.FormatUint(v, 10))
+	default:
+		if v > math.MaxInt64 {
+			return fmt.Errorf("uint64 %d overflows int64, use Uint64Decimal128 or Uint64String storage", v)
+		}
+		return vw.WriteInt64(int64(v))
+	}
+}
+
+// readNumber reads any BSON number as exact rational number r and its string representation s.
+// Float f is set for BSON double, NaN and infinities are returned as f having nil r.
+// Decimal string is accepted for uint64 as it is one of uint64 representations.
+func readNumber(vr bsonrw.ValueReader, k reflect.Kind) (r *big.Rat, f float64, s string, err error) {
+	switch vr.Type() {
+	case bsontype.Int32:
+		i, err := vr.ReadInt32()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		return big.NewRat(int64(i), 1), 0, strconv.FormatInt(int64(i), 10), nil
+	case bsontype.Int64:
+		i, err := vr.ReadInt64()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		return big.NewRat(i, 1), 0, strconv.FormatInt(i, 10), nil
+	case bsontype.Double:
+		f, err := vr.ReadDouble()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, f, s, nil
+		}
+		return new(big.Rat).SetFloat64(f), f, s, nil
+	case bsontype.Decimal128:
+		d, err := vr.ReadDecimal128()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		s := d.String()
+		switch s {
+		case "NaN":
+			return nil, math.NaN(), s, nil
+		case "Infinity":
+			return nil, math.Inf(1), s, nil
+		case "-Infinity":
+			return nil, math.Inf(-1), s, nil
+		}
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return nil, 0, "", fmt.Errorf("cannot parse Decimal128 %s", s)
+		}
+		return r, 0, s, nil
+	case bsontype.String:
+		if k != reflect.Uint64 {
+			break
+		}
+		s, err := vr.ReadString()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, 0, "", fmt.Errorf("cannot parse %q as %v", s, k)
+		}
+		return new(big.Rat).SetInt(n), 0, s, nil
+	}
+	return nil, 0, "", fmt.Errorf("cannot decode %v into %v", vr.Type(), k)
+}
@@ -0,0 +1,169 @@
+package codecs
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestNumberCoercion(t *testing.T) {
+	r := Register(bson.NewRegistryBuilder()).Build()
+
+	decimal := func(s string) primitive.Decimal128 {
+		d, err := primitive.ParseDecimal128(s)
+		if err != nil {
+			t.Fatalf("primitive.ParseDecimal128 error = %v", err)
+		}
+		return d
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		value  interface{}
+		expect *test.Data
+		reason string
+	}{
+		{name: "int32 to Int64Value", key: "int64Value", value: int32(5),
+			expect: &test.Data{Int64Value: &wrapperspb.Int64Value{Value: 5}}},
+		{name: "whole double to Int32Value", key: "int32Value", value: 3.0,
+			expect: &test.Data{Int32Value: &wrapperspb.Int32Value{Value: 3}}},
+		{name: "Decimal128 to Int64Value", key: "int64Value", value: decimal("12345678901"),
+			expect: &test.Data{Int64Value: &wrapperspb.Int64Value{Value: 12345678901}}},
+		{name: "int64 to UInt64Value", key: "uint64Value", value: int64(math.MaxInt64),
+			expect: &test.Data{Uint64Value: &wrapperspb.UInt64Value{Value: math.MaxInt64}}},
+		{name: "Decimal128 to DoubleValue", key: "doubleValue", value: decimal("1.5"),
+			expect: &test.Data{DoubleValue: &wrapperspb.DoubleValue{Value: 1.5}}},
+		{name: "int32 to FloatValue", key: "floatValue", value: int32(7),
+			expect: &test.Data{FloatValue: &wrapperspb.FloatValue{Value: 7}}},
+		{name: "fraction to Int32Value", key: "int32Value", value: 3.5,
+			reason: "value has fractional part"},
+		{name: "int64 overflows Int32Value", key: "int32Value", value: int64(math.MaxInt32 + 1),
+			reason: "value overflows"},
+		{name: "negative to UInt32Value", key: "uint32Value", value: int32(-1),
+			reason: "value overflows"},
+		{name: "NaN to Int64Value", key: "int64Value", value: decimal("NaN"),
+			reason: "value is not finite"},
+		{name: "double overflows FloatValue", key: "floatValue", value: 1e40,
+			reason: "value overflows"},
+		{name: "int64 loses precision in DoubleValue", key: "doubleValue", value: int64(1<<53 + 1),
+			reason: "value loses precision"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bson.Marshal(bson.D{{Key: tt.key, Value: tt.value}})
+			if err != nil {
+				t.Errorf("bson.Marshal error = %v", err)
+				return
+			}
+
+			var out test.Data
+			err = bson.UnmarshalWithRegistry(r, b, &out)
+			if tt.reason != "" {
+				var nerr *NumberError
+				if !errors.As(err, &nerr) || nerr.Reason != tt.reason || nerr.Path != tt.key {
+					t.Errorf("failed: expected reason=%q, error=%v", tt.reason, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(tt.expect, &out) {
+				t.Errorf("failed: expected=%v, out=%v", tt.expect, &out)
+				return
+			}
+		})
+	}
+
+	t.Run("not a number", func(t *testing.T) {
+		b, err := bson.Marshal(bson.D{{Key: "int32Value", Value: "1"}})
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err == nil {
+			t.Errorf("bson.UnmarshalWithRegistry error expected, out=%v", &out)
+			return
+		}
+	})
+}
+
+func TestUint64Storage(t *testing.T) {
+	in := &test.Data{
+		Uint64Value: &wrapperspb.UInt64Value{Value: math.MaxUint64},
+		Counter:     1<<63 + 1,
+	}
+
+	tests := []struct {
+		name    string
+		storage Uint64Storage
+		bsonT   bsontype.Type
+	}{
+		{name: "decimal128", storage: Uint64Decimal128, bsonT: bsontype.Decimal128},
+		{name: "string", storage: Uint64String, bsonT: bsontype.String},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUint64Storage(tt.storage))).Build()
+
+			b, err := bson.MarshalWithRegistry(r, in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			for _, key := range []string{"uint64Value", "counter"} {
+				v, err := bson.Raw(b).LookupErr(key)
+				if err != nil || v.Type != tt.bsonT {
+					t.Errorf("failed: %s=%v, error=%v", key, v, err)
+					return
+				}
+			}
+
+			// Any representation is decoded by default registry
+			var out test.Data
+			if err = bson.UnmarshalWithRegistry(Register(bson.NewRegistryBuilder()).Build(), b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(in, &out) {
+				t.Errorf("failed: in=%v, out=%v", in, &out)
+				return
+			}
+		})
+	}
+
+	t.Run("int64 overflow", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		for _, in := range []*test.Data{
+			{Uint64Value: &wrapperspb.UInt64Value{Value: math.MaxUint64}},
+			{Counter: math.MaxUint64},
+		} {
+			if _, err := bson.MarshalWithRegistry(r, in); err == nil {
+				t.Errorf("bson.MarshalWithRegistry error expected for %v", in)
+				return
+			}
+		}
+
+		b, err := bson.MarshalWithRegistry(r, &test.Data{Counter: math.MaxInt64})
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if v, err := bson.Raw(b).LookupErr("counter"); err != nil || v.Type != bsontype.Int64 {
+			t.Errorf("failed: counter=%v, error=%v", v, err)
+			return
+		}
+	})
+}
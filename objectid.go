@@ -0,0 +1,205 @@
+package codecs
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+)
+
+const (
+	// insertedIDKey is BSON key of MongoDB document primary key
+	insertedIDKey = "_id"
+)
+
+var (
+	// ObjectId types
+	objectIDType          = reflect.TypeOf(pmongo.ObjectId{})
+	binaryObjectIDType    = reflect.TypeOf(pmongo.BinaryObjectId{})
+	objectIDPrimitiveType = reflect.TypeOf(primitive.ObjectID{})
+
+	// Codecs describing messages for SetInsertedIDWithOptions by key naming
+	insertedIDMessageCodecs = map[KeyNaming]*messageCodec{
+		KeyProtoName: newMessageCodec(NewOptions()),
+		KeyJSONName:  newMessageCodec(NewOptions(WithKeyNaming(KeyJSONName))),
+		KeyLowercase: newMessageCodec(NewOptions(WithKeyNaming(KeyLowercase))),
+	}
+)
+
+// objectIDCodec is codec for Protobuf ObjectId
+type objectIDCodec struct {
+	emptyPolicy EmptyObjectIDPolicy
+}
+
+// EncodeValue encodes Protobuf ObjectId value to BSON value
+func (e *objectIDCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	v := messageOf(val).(*pmongo.ObjectId)
+	if v.GetValue() == "" {
+		return encodeEmptyObjectID(vw, e.emptyPolicy)
+	}
+	// Create primitive.ObjectId from string
+	id, err := v.GetObjectID()
+	if err != nil {
+		return err
+	}
+	enc, err := ectx.LookupEncoder(objectIDPrimitiveType)
+	if err != nil {
+		return err
+	}
+	return enc.EncodeValue(ectx, vw, reflect.ValueOf(id))
+}
+
+// IsTypeZero returns true for nil ObjectId and for empty ObjectId to be omitted
+func (e *objectIDCodec) IsTypeZero(i interface{}) bool {
+	v, ok := i.(*pmongo.ObjectId)
+	return ok && (v == nil || e.emptyPolicy == EmptyObjectIDOmit && v.GetValue() == "")
+}
+
+// DecodeValue decodes BSON value to ObjectId value
+func (e *objectIDCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	val, id, err := decodeObjectID(ectx, vr, val)
+	if err != nil || id == nil {
+		return err
+	}
+	setMessage(val, pmongo.NewObjectId(*id))
+	return nil
+}
+
+// binaryObjectIDCodec is codec for Protobuf BinaryObjectId.
+// BinaryObjectId is stored as BSON ObjectID the same way as ObjectId is.
+type binaryObjectIDCodec struct {
+	emptyPolicy EmptyObjectIDPolicy
+}
+
+// EncodeValue encodes Protobuf BinaryObjectId value to BSON value
+func (e *binaryObjectIDCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	v := messageOf(val).(*pmongo.BinaryObjectId)
+	if len(v.GetValue()) == 0 {
+		return encodeEmptyObjectID(vw, e.emptyPolicy)
+	}
+	id, err := v.GetObjectID()
+	if err != nil {
+		return err
+	}
+	return vw.WriteObjectID(id)
+}
+
+// IsTypeZero returns true for nil BinaryObjectId and for empty BinaryObjectId to be omitted
+func (e *binaryObjectIDCodec) IsTypeZero(i interface{}) bool {
+	v, ok := i.(*pmongo.BinaryObjectId)
+	return ok && (v == nil || e.emptyPolicy == EmptyObjectIDOmit && len(v.GetValue()) == 0)
+}
+
+// DecodeValue decodes BSON value to BinaryObjectId value
+func (e *binaryObjectIDCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	val, id, err := decodeObjectID(ectx, vr, val)
+	if err != nil || id == nil {
+		return err
+	}
+	setMessage(val, pmongo.NewBinaryObjectId(*id))
+	return nil
+}
+
+// encodeEmptyObjectID writes empty ObjectId according to policy
+func encodeEmptyObjectID(vw bsonrw.ValueWriter, policy EmptyObjectIDPolicy) error {
+	switch policy {
+	case EmptyObjectIDOmit, EmptyObjectIDNull:
+		return vw.WriteNull()
+	case EmptyObjectIDGenerate:
+		return vw.WriteObjectID(primitive.NewObjectID())
+	default:
+		return &pmongo.ErrInvalidObjectId{}
+	}
+}
+
+// decodeObjectID reads BSON ObjectID for settable ObjectId message pointer or struct val
+// and returns message struct value to set. Message pointer is allocated if it is nil.
+// BSON null and undefined set nil message and return nil object ID.
+func decodeObjectID(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) (reflect.Value, *primitive.ObjectID, error) {
+	if !val.CanSet() {
+		return val, nil, bsoncodec.ValueDecoderError{Name: "objectIDCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+	if isNullValue(vr.Type()) {
+		setNullValue(val)
+		return val, nil, readNullValue(vr)
+	}
+	dec, err := ectx.LookupDecoder(objectIDPrimitiveType)
+	if err != nil {
+		return val, nil, err
+	}
+	var id primitive.ObjectID
+	if err = dec.DecodeValue(ectx, vr, reflect.ValueOf(&id).Elem()); err != nil {
+		return val, nil, err
+	}
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	return val, &id, nil
+}
+
+// SetInsertedID sets ObjectId or BinaryObjectId field of message stored under "_id" key
+// to inserted ID returned by driver, e.g. InsertOneResult.InsertedID generated for
+// message having empty ObjectId encoded with EmptyObjectIDOmit or EmptyObjectIDGenerate policy.
+// Field is found by proto field name "_id" or "bson" struct tag (e.g. added by protoc-gen-gotag).
+func SetInsertedID(m protoadapt.MessageV1, insertedID interface{}) error {
+	return SetInsertedIDWithOptions(m, insertedID, nil)
+}
+
+// SetInsertedIDWithOptions sets field of message stored under "_id" key to inserted ID the same way
+// as SetInsertedID does, with keys derived by options codecs are registered with.
+// Default options are used if opts is nil.
+func SetInsertedIDWithOptions(m protoadapt.MessageV1, insertedID interface{}, opts *Options) error {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	id, ok := insertedID.(primitive.ObjectID)
+	if !ok {
+		return fmt.Errorf("inserted ID must be %v, but got %T", objectIDPrimitiveType, insertedID)
+	}
+	c, ok := insertedIDMessageCodecs[opts.KeyNaming]
+	if !ok {
+		c = newMessageCodec(opts)
+	}
+	pm := protoadapt.MessageV2Of(m).ProtoReflect()
+	md, err := c.describeMessage(reflect.TypeOf(m).Elem(), pm.Descriptor())
+	if err != nil {
+		return err
+	}
+	f, ok := md.fm[insertedIDKey]
+	if !ok || f.fd.Message() == nil || f.fd.IsList() || f.fd.IsMap() {
+		return fmt.Errorf("message %s has no ObjectId field stored under %q key", pm.Descriptor().FullName(), insertedIDKey)
+	}
+	var v proto.Message
+	switch f.fd.Message().FullName() {
+	case proto.MessageName(&pmongo.ObjectId{}):
+		v = pmongo.NewObjectId(id)
+	case proto.MessageName(&pmongo.BinaryObjectId{}):
+		v = pmongo.NewBinaryObjectId(id)
+	default:
+		return fmt.Errorf("field %s stored under %q key must be ObjectId, but got %s", f.fd.FullName(), insertedIDKey, f.fd.Message().FullName())
+	}
+	pm.Set(f.fd, protoreflect.ValueOfMessage(v.ProtoReflect()))
+	return nil
+}
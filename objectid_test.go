@@ -0,0 +1,124 @@
+package codecs
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestEmptyObjectIDPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy EmptyObjectIDPolicy
+		key    string
+		expect bsontype.Type
+	}{
+		{name: "omit", policy: EmptyObjectIDOmit, key: "_id", expect: bsontype.Type(0)},
+		{name: "null", policy: EmptyObjectIDNull, key: "_id", expect: bsontype.Null},
+		{name: "generate", policy: EmptyObjectIDGenerate, key: "_id", expect: bsontype.ObjectID},
+		{name: "omit binary", policy: EmptyObjectIDOmit, key: "binary_id", expect: bsontype.Type(0)},
+		{name: "generate binary", policy: EmptyObjectIDGenerate, key: "binary_id", expect: bsontype.ObjectID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithEmptyObjectIDPolicy(tt.policy))).Build()
+
+			var in interface{} = &test.Record{XId: &pmongo.ObjectId{}, Name: "qwerty"}
+			if tt.key == "binary_id" {
+				in = &test.Data{BinaryId: &pmongo.BinaryObjectId{}}
+			}
+			b, err := bson.MarshalWithRegistry(r, in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			if v, err := bson.Raw(b).LookupErr(tt.key); v.Type != tt.expect {
+				t.Errorf("failed: %s=%v, error=%v, expected type %v", tt.key, v, err, tt.expect)
+				return
+			}
+		})
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), nil).Build()
+
+		_, err := bson.MarshalWithRegistry(r, &test.Record{XId: &pmongo.ObjectId{}})
+		var e *pmongo.ErrInvalidObjectId
+		if !errors.As(err, &e) {
+			t.Errorf("bson.MarshalWithRegistry error = %v, *pmongo.ErrInvalidObjectId expected", err)
+			return
+		}
+	})
+
+	t.Run("decode null", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), nil).Build()
+
+		b, err := bson.Marshal(bson.D{{Key: "_id", Value: nil}})
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+		out := test.Record{XId: pmongo.NewObjectIdNow()}
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if out.XId != nil {
+			t.Errorf("failed: _id=%v, nil expected", out.XId)
+			return
+		}
+	})
+}
+
+func TestSetInsertedID(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	var record test.Record
+	if err := SetInsertedID(&record, id); err != nil {
+		t.Errorf("SetInsertedID error = %v", err)
+		return
+	}
+	if got, err := record.GetXId().GetObjectID(); err != nil || got != id {
+		t.Errorf("failed: _id=%v, expected=%v", record.GetXId(), id)
+		return
+	}
+
+	if err := SetInsertedID(&record, id.Hex()); err == nil {
+		t.Errorf("SetInsertedID error expected for non ObjectID inserted ID")
+		return
+	}
+	if err := SetInsertedID(&test.Data{}, id); err == nil {
+		t.Errorf("SetInsertedID error expected for message without _id field")
+		return
+	}
+
+	t.Run("JSON names", func(t *testing.T) {
+		opts := NewOptions(WithKeyNaming(KeyJSONName))
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), opts).Build()
+
+		b, err := bson.MarshalWithRegistry(r, &test.Record{XId: pmongo.NewObjectId(id), Name: "qwerty"})
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if _, err = bson.Raw(b).LookupErr(insertedIDKey); err != nil {
+			t.Errorf("failed: key _id is not found in %v", bson.Raw(b))
+			return
+		}
+		var record test.Record
+		if err = SetInsertedIDWithOptions(&record, id, opts); err != nil {
+			t.Errorf("SetInsertedIDWithOptions error = %v", err)
+			return
+		}
+		if got, err := record.GetXId().GetObjectID(); err != nil || got != id {
+			t.Errorf("failed: _id=%v, expected=%v", record.GetXId(), id)
+			return
+		}
+	})
+}
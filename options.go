@@ -0,0 +1,371 @@
+package codecs
+
+import (
+	"time"
+)
+
+// TimestampPrecision defines how part of Protobuf Timestamp finer than BSON datetime
+// millisecond precision is handled
+type TimestampPrecision int
+
+const (
+	// TimestampTruncate truncates Timestamp to milliseconds (default)
+	TimestampTruncate TimestampPrecision = iota
+	// TimestampRound rounds Timestamp to the nearest millisecond
+	TimestampRound
+)
+
+// TimestampStorage defines BSON representation of Protobuf Timestamp
+type TimestampStorage int
+
+const (
+	// TimestampDateTime stores Timestamp as BSON datetime having millisecond precision (default)
+	TimestampDateTime TimestampStorage = iota
+	// TimestampDocument stores Timestamp as embedded document {date, nanos}:
+	// BSON datetime truncated to milliseconds to be used in queries and nanoseconds of second
+	// to restore Timestamp exactly
+	TimestampDocument
+	// TimestampDecimal128 stores Timestamp as BSON Decimal128 number of seconds since Unix epoch
+	// having nanosecond precision, e.g. 1549016430.123456789
+	TimestampDecimal128
+)
+
+// TimestampRangePolicy defines how Timestamp out of range from 0001-01-01T00:00:00Z
+// to 9999-12-31T23:59:59.999999999Z is handled on both encode and decode
+type TimestampRangePolicy int
+
+const (
+	// TimestampRangeFail fails with *TimestampRangeError (default)
+	TimestampRangeFail TimestampRangePolicy = iota
+	// TimestampRangeClamp replaces Timestamp with minimal or maximal valid value
+	TimestampRangeClamp
+	// TimestampRangeNull stores Timestamp as BSON null on encode and sets nil Timestamp on decode
+	TimestampRangeNull
+)
+
+// Uint64Storage defines BSON representation of uint64 values (UInt64Value wrappers and uint64, fixed64 fields)
+type Uint64Storage int
+
+const (
+	// Uint64Int64 stores uint64 as BSON int64, values above math.MaxInt64 fail to encode (default)
+	Uint64Int64 Uint64Storage = iota
+	// Uint64Decimal128 stores uint64 as BSON Decimal128 keeping numeric comparison in queries
+	Uint64Decimal128
+	// Uint64String stores uint64 as decimal string
+	Uint64String
+)
+
+// EmptyObjectIDPolicy defines how ObjectId and BinaryObjectId having empty value are encoded
+type EmptyObjectIDPolicy int
+
+const (
+	// EmptyObjectIDFail fails with *pmongo.ErrInvalidObjectId (default)
+	EmptyObjectIDFail EmptyObjectIDPolicy = iota
+	// EmptyObjectIDOmit omits message fields having empty ObjectId, e.g. to let driver or server assign _id.
+	// Empty ObjectId which can't be omitted (element of repeated field, struct field without omitempty)
+	// is encoded as BSON null.
+	EmptyObjectIDOmit
+	// EmptyObjectIDNull encodes empty ObjectId as BSON null
+	EmptyObjectIDNull
+	// EmptyObjectIDGenerate encodes empty ObjectId as new unique ObjectID
+	EmptyObjectIDGenerate
+)
+
+// UUIDRepresentation defines BSON binary subtype and byte order UUID is stored with
+type UUIDRepresentation int
+
+const (
+	// UUIDStandard stores UUID as BSON binary subtype 4 in network byte order (default)
+	UUIDStandard UUIDRepresentation = iota
+	// UUIDPythonLegacy stores UUID as BSON binary subtype 3 in network byte order
+	// the way legacy Python driver does
+	UUIDPythonLegacy
+	// UUIDJavaLegacy stores UUID as BSON binary subtype 3 having byte order of both 8 bytes halves reversed
+	// the way legacy Java driver does
+	UUIDJavaLegacy
+	// UUIDCSharpLegacy stores UUID as BSON binary subtype 3 having first three groups in little endian byte order
+	// the way legacy C# driver does
+	UUIDCSharpLegacy
+)
+
+// NilPolicy defines how nil message fields (messages, wrappers, Timestamp, etc.) are encoded
+type NilPolicy int
+
+const (
+	// NilOmit omits nil message fields the same way as proto3 JSON mapping does (default)
+	NilOmit NilPolicy = iota
+	// NilNull encodes nil message fields as BSON null
+	NilNull
+)
+
+// KeyNaming defines how BSON keys are derived from message fields
+type KeyNaming int
+
+const (
+	// KeyProtoName uses proto field names as BSON keys (default)
+	KeyProtoName KeyNaming = iota
+	// KeyJSONName uses proto JSON names (lowerCamelCase or json_name option) as BSON keys
+	KeyJSONName
+	// KeyLowercase uses lowercased Go field names of generated structs as BSON keys the same way as
+	// bsoncodec.DefaultStructTagParser does, to read documents written before codecs were registered
+	KeyLowercase
+)
+
+// EnumRepresentation defines how proto enum values are stored
+type EnumRepresentation int
+
+const (
+	// EnumNumber stores enum values as BSON int32 numbers (default)
+	EnumNumber EnumRepresentation = iota
+	// EnumName stores enum values as BSON strings with proto enum value names
+	EnumName
+)
+
+// UnknownEnumPolicy defines how values unknown to enum declaration are handled on both encode and decode
+type UnknownEnumPolicy int
+
+const (
+	// UnknownEnumKeep keeps unknown numbers the same way as proto3 open enums do (default).
+	// Unknown names can't be kept and fail to decode with *EnumError.
+	UnknownEnumKeep UnknownEnumPolicy = iota
+	// UnknownEnumFail fails with *EnumError
+	UnknownEnumFail
+	// UnknownEnumZero replaces unknown values with zero value of enum
+	UnknownEnumZero
+)
+
+// UnknownFieldsPolicy defines how fields unknown to message are handled: proto fields in unknown field set
+// of message (e.g. received from newer version of service) and BSON keys unknown to message
+// (e.g. written by newer version of service)
+type UnknownFieldsPolicy int
+
+const (
+	// UnknownFieldsDrop drops unknown fields (default)
+	UnknownFieldsDrop UnknownFieldsPolicy = iota
+	// UnknownFieldsKeep keeps unknown fields on round-trip: unknown proto fields are stored in wire format
+	// as BSON binary under UnknownFieldsKey, unknown BSON keys are kept in unknown field set of message
+	// to be written back as they were
+	UnknownFieldsKeep
+)
+
+// UnknownAnyPolicy defines how Any documents having type URL unknown to proto type registry are decoded
+type UnknownAnyPolicy int
+
+const (
+	// UnknownAnyFail fails to decode Any document of unknown type (default)
+	UnknownAnyFail UnknownAnyPolicy = iota
+	// UnknownAnyKeep keeps Any document of unknown type as raw BSON document in Any value under
+	// stored type URL to be written back unchanged. Such value is not proto wire format and can't be unpacked.
+	UnknownAnyKeep
+)
+
+// Types is set of types which codecs are registered for
+type Types uint
+
+const (
+	// TypeWrappers is Protobuf type wrappers (BoolValue, Int32Value, etc.)
+	TypeWrappers Types = 1 << iota
+	// TypeTimestamp is Protobuf Timestamp
+	TypeTimestamp
+	// TypeDuration is Protobuf Duration
+	TypeDuration
+	// TypeStruct is Protobuf Struct, Value and ListValue
+	TypeStruct
+	// TypeAny is Protobuf Any
+	TypeAny
+	// TypeObjectID is pmongo ObjectId
+	TypeObjectID
+	// TypeMessage is any other Protobuf message encoded by generic message codec
+	TypeMessage
+	// TypeEnum is Protobuf enums generated for APIv2 outside of messages encoded by generic message codec
+	TypeEnum
+	// TypeDecimal128 is pmongo Decimal128
+	TypeDecimal128
+	// TypeUUID is pmongo UUID
+	TypeUUID
+	// TypePrimitive is pmongo Timestamp, Regex, JavaScript, DBPointer, DBRef, MinKey, MaxKey and Binary
+	// representing BSON types having no Protobuf counterpart
+	TypePrimitive
+	// TypeGeoJSON is pmongo GeoJSON geometries (Point, Polygon, Geometry, etc.)
+	TypeGeoJSON
+
+	// TypeAll is all supported types (default)
+	TypeAll = TypeWrappers | TypeTimestamp | TypeDuration | TypeStruct | TypeAny | TypeObjectID | TypeMessage | TypeEnum |
+		TypeDecimal128 | TypeUUID | TypePrimitive | TypeGeoJSON
+)
+
+// Options is configuration of codecs registered by RegisterWithOptions
+type Options struct {
+	// TimestampPrecision defines how Timestamp is truncated to BSON datetime milliseconds
+	TimestampPrecision TimestampPrecision
+	// TimestampStorage defines BSON representation of Timestamp
+	TimestampStorage TimestampStorage
+	// TimestampRangePolicy defines how Timestamp out of valid range is handled
+	TimestampRangePolicy TimestampRangePolicy
+	// DurationUnit is unit of BSON int64 value Duration is stored in, zero means nanoseconds
+	DurationUnit time.Duration
+	// Uint64Storage defines BSON representation of uint64 values
+	Uint64Storage Uint64Storage
+	// EmptyObjectIDPolicy defines how empty ObjectId is encoded
+	EmptyObjectIDPolicy EmptyObjectIDPolicy
+	// UUIDRepresentation defines BSON binary subtype and byte order of UUID
+	UUIDRepresentation UUIDRepresentation
+	// NilPolicy defines how nil message fields are encoded
+	NilPolicy NilPolicy
+	// KeyNaming defines how BSON keys are derived from message fields
+	KeyNaming KeyNaming
+	// EnumRepresentation defines how enum fields are stored
+	EnumRepresentation EnumRepresentation
+	// UnknownEnumPolicy defines how values unknown to enum declaration are handled
+	UnknownEnumPolicy UnknownEnumPolicy
+	// UnknownFieldsPolicy defines how fields unknown to message are handled
+	UnknownFieldsPolicy UnknownFieldsPolicy
+	// AnyTypeKey is BSON document key keeping Any type URL
+	AnyTypeKey string
+	// UnknownAnyPolicy defines how Any documents of types unknown to proto type registry are decoded
+	UnknownAnyPolicy UnknownAnyPolicy
+	// Types is set of types to register codecs for
+	Types Types
+}
+
+// Option is functional option to configure codecs
+type Option func(*Options)
+
+// NewOptions creates options having default values overridden by provided functional options
+func NewOptions(opts ...Option) *Options {
+	o := &Options{
+		TimestampPrecision:   TimestampTruncate,
+		TimestampStorage:     TimestampDateTime,
+		TimestampRangePolicy: TimestampRangeFail,
+		DurationUnit:         time.Nanosecond,
+		Uint64Storage:        Uint64Int64,
+		EmptyObjectIDPolicy:  EmptyObjectIDFail,
+		UUIDRepresentation:   UUIDStandard,
+		NilPolicy:            NilOmit,
+		KeyNaming:            KeyProtoName,
+		EnumRepresentation:   EnumNumber,
+		UnknownEnumPolicy:    UnknownEnumKeep,
+		UnknownFieldsPolicy:  UnknownFieldsDrop,
+		AnyTypeKey:           DefaultAnyTypeKey,
+		UnknownAnyPolicy:     UnknownAnyFail,
+		Types:                TypeAll,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithTimestampPrecision sets how Timestamp is truncated to BSON datetime milliseconds
+func WithTimestampPrecision(p TimestampPrecision) Option {
+	return func(o *Options) {
+		o.TimestampPrecision = p
+	}
+}
+
+// WithTimestampStorage sets BSON representation of Timestamp.
+// Timestamp stored in any of representations is decoded regardless of this option.
+func WithTimestampStorage(s TimestampStorage) Option {
+	return func(o *Options) {
+		o.TimestampStorage = s
+	}
+}
+
+// WithTimestampRangePolicy sets how Timestamp out of valid range is handled,
+// e.g. TimestampRangeClamp to read documents having sentinel dates
+func WithTimestampRangePolicy(p TimestampRangePolicy) Option {
+	return func(o *Options) {
+		o.TimestampRangePolicy = p
+	}
+}
+
+// WithDurationUnit sets unit of BSON int64 value Duration is stored in, e.g. time.Second
+func WithDurationUnit(unit time.Duration) Option {
+	return func(o *Options) {
+		o.DurationUnit = unit
+	}
+}
+
+// WithUint64Storage sets BSON representation of uint64 values.
+// Values stored in any of representations are decoded regardless of this option.
+func WithUint64Storage(s Uint64Storage) Option {
+	return func(o *Options) {
+		o.Uint64Storage = s
+	}
+}
+
+// WithEmptyObjectIDPolicy sets how empty ObjectId is encoded,
+// e.g. EmptyObjectIDOmit to let driver assign _id and SetInsertedID to get it back into message
+func WithEmptyObjectIDPolicy(p EmptyObjectIDPolicy) Option {
+	return func(o *Options) {
+		o.EmptyObjectIDPolicy = p
+	}
+}
+
+// WithUUIDRepresentation sets BSON binary subtype and byte order of UUID,
+// e.g. UUIDJavaLegacy to share collection with applications using legacy Java driver.
+// Binary subtype 4 is decoded as standard UUID regardless of this option.
+func WithUUIDRepresentation(r UUIDRepresentation) Option {
+	return func(o *Options) {
+		o.UUIDRepresentation = r
+	}
+}
+
+// WithNilPolicy sets how nil message fields are encoded
+func WithNilPolicy(p NilPolicy) Option {
+	return func(o *Options) {
+		o.NilPolicy = p
+	}
+}
+
+// WithKeyNaming sets how BSON keys are derived from message fields
+func WithKeyNaming(k KeyNaming) Option {
+	return func(o *Options) {
+		o.KeyNaming = k
+	}
+}
+
+// WithEnumRepresentation sets how enum fields are stored
+func WithEnumRepresentation(r EnumRepresentation) Option {
+	return func(o *Options) {
+		o.EnumRepresentation = r
+	}
+}
+
+// WithUnknownEnumPolicy sets how values unknown to enum declaration are handled,
+// e.g. UnknownEnumZero to read documents written by newer version of enum
+func WithUnknownEnumPolicy(p UnknownEnumPolicy) Option {
+	return func(o *Options) {
+		o.UnknownEnumPolicy = p
+	}
+}
+
+// WithUnknownFieldsPolicy sets how fields unknown to message are handled,
+// e.g. UnknownFieldsKeep to not destroy data written by newer version of message
+func WithUnknownFieldsPolicy(p UnknownFieldsPolicy) Option {
+	return func(o *Options) {
+		o.UnknownFieldsPolicy = p
+	}
+}
+
+// WithAnyTypeKey sets BSON document key keeping Any type URL
+func WithAnyTypeKey(key string) Option {
+	return func(o *Options) {
+		o.AnyTypeKey = key
+	}
+}
+
+// WithUnknownAnyPolicy sets how Any documents of types unknown to proto type registry are decoded,
+// e.g. UnknownAnyKeep to read documents written by other services
+func WithUnknownAnyPolicy(p UnknownAnyPolicy) Option {
+	return func(o *Options) {
+		o.UnknownAnyPolicy = p
+	}
+}
+
+// WithTypes sets types to register codecs for, e.g. TypeAll &^ TypeMessage
+func WithTypes(t Types) Option {
+	return func(o *Options) {
+		o.Types = t
+	}
+}
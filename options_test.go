@@ -0,0 +1,154 @@
+package codecs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestRegisterWithOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   *Options
+		in     *test.Data
+		key    string
+		expect interface{}
+	}{
+		{
+			name:   "duration unit",
+			opts:   NewOptions(WithDurationUnit(time.Second)),
+			in:     &test.Data{Duration: durationpb.New(90 * time.Second)},
+			key:    "duration",
+			expect: int64(90),
+		},
+		{
+			name:   "zero options",
+			opts:   &Options{Types: TypeDuration | TypeMessage},
+			in:     &test.Data{Duration: durationpb.New(90 * time.Second)},
+			key:    "duration",
+			expect: int64(90 * time.Second),
+		},
+		{
+			name:   "timestamp rounding",
+			opts:   NewOptions(WithTimestampPrecision(TimestampRound)),
+			in:     &test.Data{Timestamp: &timestamppb.Timestamp{Seconds: 1, Nanos: 999999999}},
+			key:    "timestamp",
+			expect: time.Unix(2, 0).UTC(),
+		},
+		{
+			name:   "nil as null",
+			opts:   NewOptions(WithNilPolicy(NilNull)),
+			in:     &test.Data{},
+			key:    "timestamp",
+			expect: nil,
+		},
+		{
+			name:   "lowercased Go names",
+			opts:   NewOptions(WithKeyNaming(KeyLowercase)),
+			in:     &test.Data{SnakeCase: "qwerty"},
+			key:    "snakecase",
+			expect: "qwerty",
+		},
+		{
+			name:   "enum names",
+			opts:   NewOptions(WithEnumRepresentation(EnumName)),
+			in:     &test.Data{Color: test.Color_RED, Colors: []test.Color{test.Color_GREEN, 5}},
+			key:    "colors",
+			expect: bson.A{"GREEN", int32(5)},
+		},
+		{
+			name:   "any type key",
+			opts:   NewOptions(WithAnyTypeKey("_t")),
+			in:     &test.Data{Any: mustMarshalAny(t, &test.Data{SnakeCase: "qwerty"})},
+			key:    "any",
+			expect: bson.D{{Key: "_t", Value: "type.googleapis.com/test.Data"}, {Key: "snake_case", Value: "qwerty"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RegisterWithOptions(bson.NewRegistryBuilder(), tt.opts).Build()
+
+			b, err := bson.MarshalWithRegistry(r, tt.in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+
+			var doc bson.D
+			if err = bson.Unmarshal(b, &doc); err != nil {
+				t.Errorf("bson.Unmarshal error = %v", err)
+				return
+			}
+			var v interface{}
+			found := false
+			for _, e := range doc {
+				if e.Key == tt.key {
+					v, found = e.Value, true
+				}
+			}
+			if !found {
+				t.Errorf("failed: key %q is not found in %v", tt.key, doc)
+				return
+			}
+			if dt, ok := v.(primitive.DateTime); ok {
+				v = time.Unix(0, int64(dt)*int64(time.Millisecond)).UTC()
+			}
+			if !reflect.DeepEqual(tt.expect, v) {
+				t.Errorf("failed: expected=%#v, value=%#v", tt.expect, v)
+				return
+			}
+
+			var out test.Data
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+		})
+	}
+
+	t.Run("duration precision", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithDurationUnit(time.Second))).Build()
+
+		if _, err := bson.MarshalWithRegistry(r, &test.Data{Duration: durationpb.New(1500 * time.Millisecond)}); err == nil {
+			t.Errorf("bson.MarshalWithRegistry error expected for duration not being multiple of unit")
+			return
+		}
+	})
+
+	t.Run("types", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTypes(TypeAll&^TypeDuration))).Build()
+
+		b, err := bson.MarshalWithRegistry(r, &test.Data{Duration: durationpb.New(time.Second)})
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		v, err := bson.Raw(b).LookupErr("duration")
+		if err != nil {
+			t.Errorf("bson.Raw.LookupErr error = %v", err)
+			return
+		}
+		if v.Type != bsontype.EmbeddedDocument {
+			t.Errorf("failed: duration=%v", v)
+			return
+		}
+	})
+}
+
+func mustMarshalAny(t *testing.T, pb proto.Message) *anypb.Any {
+	a, err := anypb.New(pb)
+	if err != nil {
+		t.Fatalf("anypb.New error = %v", err)
+	}
+	return a
+}
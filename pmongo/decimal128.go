@@ -0,0 +1,68 @@
+package pmongo
+
+import (
+	"fmt"
+	"math/big"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// decimal128Digits is maximal number of significant decimal digits of Decimal128
+const decimal128Digits = 34
+
+// NewDecimal128 creates proto Decimal128 from MongoDB Decimal128
+func NewDecimal128(d primitive.Decimal128) *Decimal128 {
+	h, l := d.GetBytes()
+	return &Decimal128{High: h, Low: l}
+}
+
+// ParseDecimal128 creates proto Decimal128 from decimal string, e.g. "123.45", "-1E+10", "NaN" or "Infinity".
+// It fails if string has more than 34 significant digits or exponent out of Decimal128 range.
+func ParseDecimal128(s string) (*Decimal128, error) {
+	d, err := primitive.ParseDecimal128(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimal128(d), nil
+}
+
+// NewDecimal128FromBigFloat creates proto Decimal128 from big.Float.
+// Value is rounded to 34 significant digits if shortest decimal representing f at its precision is longer.
+func NewDecimal128FromBigFloat(f *big.Float) (*Decimal128, error) {
+	if f.IsInf() {
+		if f.Sign() < 0 {
+			return ParseDecimal128("-Infinity")
+		}
+		return ParseDecimal128("Infinity")
+	}
+	s := f.Text('e', -1)
+	if d, err := ParseDecimal128(s); err == nil {
+		return d, nil
+	}
+	return ParseDecimal128(f.Text('e', decimal128Digits-1))
+}
+
+// GetDecimal128 returns MongoDB Decimal128
+func (d *Decimal128) GetDecimal128() primitive.Decimal128 {
+	return primitive.NewDecimal128(d.GetHigh(), d.GetLow())
+}
+
+// BigFloat returns value as big.Float having precision enough to keep 34 decimal digits.
+// It fails for NaN which can't be represented by big.Float.
+func (d *Decimal128) BigFloat() (*big.Float, error) {
+	s := d.GetDecimal128().String()
+	switch s {
+	case "NaN":
+		return nil, fmt.Errorf("Decimal128 %s can't be represented by big.Float", s)
+	case "Infinity":
+		return new(big.Float).SetInf(false), nil
+	case "-Infinity":
+		return new(big.Float).SetInf(true), nil
+	}
+	// 34 decimal digits need 113 bits of mantissa
+	f, _, err := big.ParseFloat(s, 10, 113, big.ToNearestEven)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,133 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pmongo/decimal128.proto
+
+package pmongo
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Decimal128 is MongoDB Decimal128 value kept as high and low 64 bits of IEEE 754-2008 128-bit decimal
+type Decimal128 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	High          uint64                 `protobuf:"varint,1,opt,name=high,proto3" json:"high,omitempty"`
+	Low           uint64                 `protobuf:"varint,2,opt,name=low,proto3" json:"low,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decimal128) Reset() {
+	*x = Decimal128{}
+	mi := &file_pmongo_decimal128_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decimal128) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal128) ProtoMessage() {}
+
+func (x *Decimal128) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_decimal128_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal128.ProtoReflect.Descriptor instead.
+func (*Decimal128) Descriptor() ([]byte, []int) {
+	return file_pmongo_decimal128_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Decimal128) GetHigh() uint64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Decimal128) GetLow() uint64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+var File_pmongo_decimal128_proto protoreflect.FileDescriptor
+
+const file_pmongo_decimal128_proto_rawDesc = "" +
+	"\n" +
+	"\x17pmongo/decimal128.proto\x12\x06pmongo\"2\n" +
+	"\n" +
+	"Decimal128\x12\x12\n" +
+	"\x04high\x18\x01 \x01(\x04R\x04high\x12\x10\n" +
+	"\x03low\x18\x02 \x01(\x04R\x03lowB4Z2github.com/amsokol/mongo-go-driver-protobuf/pmongob\x06proto3"
+
+var (
+	file_pmongo_decimal128_proto_rawDescOnce sync.Once
+	file_pmongo_decimal128_proto_rawDescData []byte
+)
+
+func file_pmongo_decimal128_proto_rawDescGZIP() []byte {
+	file_pmongo_decimal128_proto_rawDescOnce.Do(func() {
+		file_pmongo_decimal128_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pmongo_decimal128_proto_rawDesc), len(file_pmongo_decimal128_proto_rawDesc)))
+	})
+	return file_pmongo_decimal128_proto_rawDescData
+}
+
+var file_pmongo_decimal128_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pmongo_decimal128_proto_goTypes = []any{
+	(*Decimal128)(nil), // 0: pmongo.Decimal128
+}
+var file_pmongo_decimal128_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pmongo_decimal128_proto_init() }
+func file_pmongo_decimal128_proto_init() {
+	if File_pmongo_decimal128_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pmongo_decimal128_proto_rawDesc), len(file_pmongo_decimal128_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pmongo_decimal128_proto_goTypes,
+		DependencyIndexes: file_pmongo_decimal128_proto_depIdxs,
+		MessageInfos:      file_pmongo_decimal128_proto_msgTypes,
+	}.Build()
+	File_pmongo_decimal128_proto = out.File
+	file_pmongo_decimal128_proto_goTypes = nil
+	file_pmongo_decimal128_proto_depIdxs = nil
+}
@@ -0,0 +1,96 @@
+package pmongo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDecimal128(t *testing.T) {
+	t.Run("primitive", func(t *testing.T) {
+		p, err := primitive.ParseDecimal128("1234567890.0123456789")
+		if err != nil {
+			t.Errorf("primitive.ParseDecimal128 error = %v", err)
+			return
+		}
+		if d := NewDecimal128(p); d.GetDecimal128() != p {
+			t.Errorf("failed: d=%v, expected=%v", d.GetDecimal128(), p)
+			return
+		}
+		if _, err = ParseDecimal128("1.2.3"); err == nil {
+			t.Errorf("ParseDecimal128 error expected for invalid value")
+			return
+		}
+	})
+
+	t.Run("big.Float", func(t *testing.T) {
+		for _, s := range []string{"0", "-1.5", "0.1", "1234567890123456789012345678901234", "1E+6000", "-Infinity"} {
+			d, err := ParseDecimal128(s)
+			if err != nil {
+				t.Errorf("ParseDecimal128 error = %v", err)
+				return
+			}
+			f, err := d.BigFloat()
+			if err != nil {
+				t.Errorf("Decimal128.BigFloat error = %v", err)
+				return
+			}
+			out, err := NewDecimal128FromBigFloat(f)
+			if err != nil {
+				t.Errorf("NewDecimal128FromBigFloat error = %v", err)
+				return
+			}
+			if out.GetDecimal128().String() != d.GetDecimal128().String() {
+				t.Errorf("failed: in=%v, out=%v", d.GetDecimal128(), out.GetDecimal128())
+				return
+			}
+		}
+
+		// 1/3 is rounded to 34 digits
+		d, err := NewDecimal128FromBigFloat(new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3)))
+		if err != nil {
+			t.Errorf("NewDecimal128FromBigFloat error = %v", err)
+			return
+		}
+		if s := d.GetDecimal128().String(); s != "0.3333333333333333333333333333333333" {
+			t.Errorf("failed: d=%s", s)
+			return
+		}
+
+		if _, err = (&Decimal128{High: 0x1F << 58}).BigFloat(); err == nil {
+			t.Errorf("Decimal128.BigFloat error expected for NaN")
+			return
+		}
+	})
+
+	t.Run("jsonpb", func(t *testing.T) {
+		d, _ := ParseDecimal128("-12.340")
+		s, err := new(jsonpb.Marshaler).MarshalToString(d)
+		if err != nil {
+			t.Errorf("jsonpb.Marshaler error = %v", err)
+			return
+		}
+		if s != `"-12.340"` {
+			t.Errorf("failed: json=%s", s)
+			return
+		}
+		for _, data := range []string{`"-12.340"`, `-12.340`} {
+			var out Decimal128
+			if err = jsonpb.UnmarshalString(data, &out); err != nil {
+				t.Errorf("jsonpb.UnmarshalString error = %v", err)
+				return
+			}
+			if out.GetDecimal128() != d.GetDecimal128() {
+				t.Errorf("failed: json=%s, out=%v", data, out.GetDecimal128())
+				return
+			}
+		}
+		var out Decimal128
+		if err = jsonpb.UnmarshalString(`"abc"`, &out); err == nil {
+			t.Errorf("jsonpb.UnmarshalString error expected for invalid value")
+			return
+		}
+	})
+}
@@ -0,0 +1,313 @@
+package pmongo
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// GeoJSON geometry types
+const (
+	GeoJSONPoint              = "Point"
+	GeoJSONLineString         = "LineString"
+	GeoJSONPolygon            = "Polygon"
+	GeoJSONMultiPoint         = "MultiPoint"
+	GeoJSONMultiLineString    = "MultiLineString"
+	GeoJSONMultiPolygon       = "MultiPolygon"
+	GeoJSONGeometryCollection = "GeometryCollection"
+)
+
+// GeoJSONGeometry is implemented by GeoJSON geometry messages
+type GeoJSONGeometry interface {
+	proto.Message
+	// GeoJSONType returns GeoJSON type of geometry, e.g. "Point"
+	GeoJSONType() string
+	// Validate returns *InvalidGeoJSONError if geometry is not valid
+	Validate() error
+}
+
+// InvalidGeoJSONError is error of GeoJSON geometry MongoDB rejects to store in 2dsphere index
+type InvalidGeoJSONError struct {
+	// Type is GeoJSON type of geometry, e.g. "Polygon"
+	Type string
+	// Path is path of invalid coordinates in geometry, e.g. "coordinates.0.3"
+	Path string
+	// Reason describes violated constraint
+	Reason string
+}
+
+// Error returns error message
+func (e *InvalidGeoJSONError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("invalid GeoJSON %s: %s", e.Type, e.Reason)
+	}
+	return fmt.Sprintf("invalid GeoJSON %s: %s: %s", e.Type, e.Path, e.Reason)
+}
+
+// NewPosition creates proto GeoJSON position
+func NewPosition(longitude, latitude float64) *Position {
+	return &Position{Longitude: longitude, Latitude: latitude}
+}
+
+// NewPoint creates proto GeoJSON Point
+func NewPoint(longitude, latitude float64) *Point {
+	return &Point{Coordinates: NewPosition(longitude, latitude)}
+}
+
+// NewGeometry creates proto Geometry keeping GeoJSON geometry, e.g. to add it to GeometryCollection
+func NewGeometry(g GeoJSONGeometry) (*Geometry, error) {
+	switch v := g.(type) {
+	case *Point:
+		return &Geometry{Geometry: &Geometry_Point{Point: v}}, nil
+	case *LineString:
+		return &Geometry{Geometry: &Geometry_LineString{LineString: v}}, nil
+	case *Polygon:
+		return &Geometry{Geometry: &Geometry_Polygon{Polygon: v}}, nil
+	case *MultiPoint:
+		return &Geometry{Geometry: &Geometry_MultiPoint{MultiPoint: v}}, nil
+	case *MultiLineString:
+		return &Geometry{Geometry: &Geometry_MultiLineString{MultiLineString: v}}, nil
+	case *MultiPolygon:
+		return &Geometry{Geometry: &Geometry_MultiPolygon{MultiPolygon: v}}, nil
+	case *GeometryCollection:
+		return &Geometry{Geometry: &Geometry_GeometryCollection{GeometryCollection: v}}, nil
+	case *Geometry:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("%T is not GeoJSON geometry", g)
+	}
+}
+
+// GetGeoJSONGeometry returns geometry kept by Geometry or nil if it is not set
+func (g *Geometry) GetGeoJSONGeometry() GeoJSONGeometry {
+	switch v := g.GetGeometry().(type) {
+	case *Geometry_Point:
+		return v.Point
+	case *Geometry_LineString:
+		return v.LineString
+	case *Geometry_Polygon:
+		return v.Polygon
+	case *Geometry_MultiPoint:
+		return v.MultiPoint
+	case *Geometry_MultiLineString:
+		return v.MultiLineString
+	case *Geometry_MultiPolygon:
+		return v.MultiPolygon
+	case *Geometry_GeometryCollection:
+		return v.GeometryCollection
+	default:
+		return nil
+	}
+}
+
+// GeoJSONType returns "Point"
+func (*Point) GeoJSONType() string {
+	return GeoJSONPoint
+}
+
+// GeoJSONType returns "LineString"
+func (*LineString) GeoJSONType() string {
+	return GeoJSONLineString
+}
+
+// GeoJSONType returns "Polygon"
+func (*Polygon) GeoJSONType() string {
+	return GeoJSONPolygon
+}
+
+// GeoJSONType returns "MultiPoint"
+func (*MultiPoint) GeoJSONType() string {
+	return GeoJSONMultiPoint
+}
+
+// GeoJSONType returns "MultiLineString"
+func (*MultiLineString) GeoJSONType() string {
+	return GeoJSONMultiLineString
+}
+
+// GeoJSONType returns "MultiPolygon"
+func (*MultiPolygon) GeoJSONType() string {
+	return GeoJSONMultiPolygon
+}
+
+// GeoJSONType returns "GeometryCollection"
+func (*GeometryCollection) GeoJSONType() string {
+	return GeoJSONGeometryCollection
+}
+
+// GeoJSONType returns GeoJSON type of geometry kept by Geometry or empty string if it is not set
+func (g *Geometry) GeoJSONType() string {
+	if v := g.GetGeoJSONGeometry(); v != nil {
+		return v.GeoJSONType()
+	}
+	return ""
+}
+
+// Validate returns *InvalidGeoJSONError if position is not set or longitude or latitude is out of range
+func (p *Position) Validate() error {
+	if reason := validatePosition(p); reason != "" {
+		return &InvalidGeoJSONError{Type: "position", Reason: reason}
+	}
+	return nil
+}
+
+// Validate returns *InvalidGeoJSONError if Point has no valid position
+func (p *Point) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if reason := validatePosition(p.Coordinates); reason != "" {
+		return &InvalidGeoJSONError{Type: GeoJSONPoint, Path: "coordinates", Reason: reason}
+	}
+	return nil
+}
+
+// Validate returns *InvalidGeoJSONError if LineString has less than two positions or invalid positions
+func (l *LineString) Validate() error {
+	if l == nil {
+		return nil
+	}
+	if path, reason := validateLineString(l.Coordinates, "coordinates"); reason != "" {
+		return &InvalidGeoJSONError{Type: GeoJSONLineString, Path: path, Reason: reason}
+	}
+	return nil
+}
+
+// Validate returns *InvalidGeoJSONError if Polygon has no rings, rings are not closed,
+// have less than four positions or invalid positions
+func (p *Polygon) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if path, reason := validatePolygon(p, "coordinates"); reason != "" {
+		return &InvalidGeoJSONError{Type: GeoJSONPolygon, Path: path, Reason: reason}
+	}
+	return nil
+}
+
+// Validate returns *InvalidGeoJSONError if MultiPoint has invalid positions
+func (m *MultiPoint) Validate() error {
+	if m == nil {
+		return nil
+	}
+	for i, p := range m.Coordinates {
+		if reason := validatePosition(p); reason != "" {
+			return &InvalidGeoJSONError{Type: GeoJSONMultiPoint, Path: fmt.Sprintf("coordinates.%d", i), Reason: reason}
+		}
+	}
+	return nil
+}
+
+// Validate returns *InvalidGeoJSONError if MultiLineString has invalid line strings
+func (m *MultiLineString) Validate() error {
+	if m == nil {
+		return nil
+	}
+	for i, l := range m.Coordinates {
+		if path, reason := validateLineString(l.GetCoordinates(), fmt.Sprintf("coordinates.%d", i)); reason != "" {
+			return &InvalidGeoJSONError{Type: GeoJSONMultiLineString, Path: path, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// Validate returns *InvalidGeoJSONError if MultiPolygon has invalid polygons
+func (m *MultiPolygon) Validate() error {
+	if m == nil {
+		return nil
+	}
+	for i, p := range m.Coordinates {
+		if path, reason := validatePolygon(p, fmt.Sprintf("coordinates.%d", i)); reason != "" {
+			return &InvalidGeoJSONError{Type: GeoJSONMultiPolygon, Path: path, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// Validate returns *InvalidGeoJSONError if GeometryCollection has unset or invalid geometries
+func (c *GeometryCollection) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for i, g := range c.Geometries {
+		path := fmt.Sprintf("geometries.%d", i)
+		if g.GetGeoJSONGeometry() == nil {
+			return &InvalidGeoJSONError{Type: GeoJSONGeometryCollection, Path: path, Reason: "geometry is not set"}
+		}
+		err := g.Validate()
+		if e, ok := err.(*InvalidGeoJSONError); ok {
+			if e.Path != "" {
+				path += "." + e.Path
+			}
+			return &InvalidGeoJSONError{Type: GeoJSONGeometryCollection, Path: path, Reason: e.Type + ": " + e.Reason}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate returns *InvalidGeoJSONError if Geometry is not set or kept geometry is not valid
+func (g *Geometry) Validate() error {
+	if g == nil {
+		return nil
+	}
+	v := g.GetGeoJSONGeometry()
+	if v == nil {
+		return &InvalidGeoJSONError{Type: "geometry", Reason: "geometry is not set"}
+	}
+	return v.Validate()
+}
+
+// validatePosition returns reason why position is not valid or empty string
+func validatePosition(p *Position) string {
+	switch {
+	case p == nil:
+		return "position is not set"
+	case math.IsNaN(p.Longitude) || p.Longitude < -180 || p.Longitude > 180:
+		return fmt.Sprintf("longitude %v is out of range from -180 to 180", p.Longitude)
+	case math.IsNaN(p.Latitude) || p.Latitude < -90 || p.Latitude > 90:
+		return fmt.Sprintf("latitude %v is out of range from -90 to 90", p.Latitude)
+	default:
+		return ""
+	}
+}
+
+// validateLineString returns path and reason why line string positions are not valid or empty strings
+func validateLineString(ps []*Position, path string) (string, string) {
+	if len(ps) < 2 {
+		return path, fmt.Sprintf("line string must have at least 2 positions, but got %d", len(ps))
+	}
+	for i, p := range ps {
+		if reason := validatePosition(p); reason != "" {
+			return fmt.Sprintf("%s.%d", path, i), reason
+		}
+	}
+	return "", ""
+}
+
+// validatePolygon returns path and reason why polygon is not valid or empty strings
+func validatePolygon(p *Polygon, path string) (string, string) {
+	if len(p.GetCoordinates()) == 0 {
+		return path, "polygon must have exterior ring"
+	}
+	for i, r := range p.Coordinates {
+		ringPath := fmt.Sprintf("%s.%d", path, i)
+		ps := r.GetCoordinates()
+		if len(ps) < 4 {
+			return ringPath, fmt.Sprintf("linear ring must have at least 4 positions, but got %d", len(ps))
+		}
+		for j, p := range ps {
+			if reason := validatePosition(p); reason != "" {
+				return fmt.Sprintf("%s.%d", ringPath, j), reason
+			}
+		}
+		first, last := ps[0], ps[len(ps)-1]
+		if first.Longitude != last.Longitude || first.Latitude != last.Latitude {
+			return ringPath, "linear ring is not closed: first and last positions must be the same"
+		}
+	}
+	return "", ""
+}
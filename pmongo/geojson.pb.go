@@ -0,0 +1,723 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pmongo/geojson.proto
+
+package pmongo
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Position is GeoJSON position stored as [longitude, latitude] array
+type Position struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Longitude in degrees from -180 to 180
+	Longitude float64 `protobuf:"fixed64,1,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Latitude in degrees from -90 to 90
+	Latitude      float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_pmongo_geojson_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Position) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Position) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+// Point is GeoJSON Point stored as {type: "Point", coordinates: [longitude, latitude]}
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates   *Position              `protobuf:"bytes,1,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_pmongo_geojson_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Point) GetCoordinates() *Position {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// LineString is GeoJSON LineString having two or more positions
+type LineString struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates   []*Position            `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineString) Reset() {
+	*x = LineString{}
+	mi := &file_pmongo_geojson_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineString) ProtoMessage() {}
+
+func (x *LineString) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineString.ProtoReflect.Descriptor instead.
+func (*LineString) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LineString) GetCoordinates() []*Position {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// LinearRing is closed LineString having four or more positions with the same first and last positions
+type LinearRing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates   []*Position            `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinearRing) Reset() {
+	*x = LinearRing{}
+	mi := &file_pmongo_geojson_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinearRing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinearRing) ProtoMessage() {}
+
+func (x *LinearRing) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinearRing.ProtoReflect.Descriptor instead.
+func (*LinearRing) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LinearRing) GetCoordinates() []*Position {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// Polygon is GeoJSON Polygon having exterior ring first and optional interior rings (holes)
+type Polygon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates   []*LinearRing          `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Polygon) Reset() {
+	*x = Polygon{}
+	mi := &file_pmongo_geojson_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Polygon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Polygon) ProtoMessage() {}
+
+func (x *Polygon) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Polygon.ProtoReflect.Descriptor instead.
+func (*Polygon) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Polygon) GetCoordinates() []*LinearRing {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// MultiPoint is GeoJSON MultiPoint
+type MultiPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates   []*Position            `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiPoint) Reset() {
+	*x = MultiPoint{}
+	mi := &file_pmongo_geojson_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiPoint) ProtoMessage() {}
+
+func (x *MultiPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiPoint.ProtoReflect.Descriptor instead.
+func (*MultiPoint) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MultiPoint) GetCoordinates() []*Position {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// MultiLineString is GeoJSON MultiLineString
+type MultiLineString struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates   []*LineString          `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiLineString) Reset() {
+	*x = MultiLineString{}
+	mi := &file_pmongo_geojson_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiLineString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiLineString) ProtoMessage() {}
+
+func (x *MultiLineString) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiLineString.ProtoReflect.Descriptor instead.
+func (*MultiLineString) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MultiLineString) GetCoordinates() []*LineString {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// MultiPolygon is GeoJSON MultiPolygon
+type MultiPolygon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates   []*Polygon             `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiPolygon) Reset() {
+	*x = MultiPolygon{}
+	mi := &file_pmongo_geojson_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiPolygon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiPolygon) ProtoMessage() {}
+
+func (x *MultiPolygon) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiPolygon.ProtoReflect.Descriptor instead.
+func (*MultiPolygon) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MultiPolygon) GetCoordinates() []*Polygon {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+// GeometryCollection is GeoJSON GeometryCollection stored as {type: "GeometryCollection", geometries: [...]}
+type GeometryCollection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Geometries    []*Geometry            `protobuf:"bytes,1,rep,name=geometries,proto3" json:"geometries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeometryCollection) Reset() {
+	*x = GeometryCollection{}
+	mi := &file_pmongo_geojson_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeometryCollection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeometryCollection) ProtoMessage() {}
+
+func (x *GeometryCollection) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeometryCollection.ProtoReflect.Descriptor instead.
+func (*GeometryCollection) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GeometryCollection) GetGeometries() []*Geometry {
+	if x != nil {
+		return x.Geometries
+	}
+	return nil
+}
+
+// Geometry is any GeoJSON geometry, e.g. member of GeometryCollection or field keeping geometries of different types
+type Geometry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Geometry:
+	//
+	//	*Geometry_Point
+	//	*Geometry_LineString
+	//	*Geometry_Polygon
+	//	*Geometry_MultiPoint
+	//	*Geometry_MultiLineString
+	//	*Geometry_MultiPolygon
+	//	*Geometry_GeometryCollection
+	Geometry      isGeometry_Geometry `protobuf_oneof:"geometry"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Geometry) Reset() {
+	*x = Geometry{}
+	mi := &file_pmongo_geojson_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Geometry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Geometry) ProtoMessage() {}
+
+func (x *Geometry) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_geojson_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Geometry.ProtoReflect.Descriptor instead.
+func (*Geometry) Descriptor() ([]byte, []int) {
+	return file_pmongo_geojson_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Geometry) GetGeometry() isGeometry_Geometry {
+	if x != nil {
+		return x.Geometry
+	}
+	return nil
+}
+
+func (x *Geometry) GetPoint() *Point {
+	if x != nil {
+		if x, ok := x.Geometry.(*Geometry_Point); ok {
+			return x.Point
+		}
+	}
+	return nil
+}
+
+func (x *Geometry) GetLineString() *LineString {
+	if x != nil {
+		if x, ok := x.Geometry.(*Geometry_LineString); ok {
+			return x.LineString
+		}
+	}
+	return nil
+}
+
+func (x *Geometry) GetPolygon() *Polygon {
+	if x != nil {
+		if x, ok := x.Geometry.(*Geometry_Polygon); ok {
+			return x.Polygon
+		}
+	}
+	return nil
+}
+
+func (x *Geometry) GetMultiPoint() *MultiPoint {
+	if x != nil {
+		if x, ok := x.Geometry.(*Geometry_MultiPoint); ok {
+			return x.MultiPoint
+		}
+	}
+	return nil
+}
+
+func (x *Geometry) GetMultiLineString() *MultiLineString {
+	if x != nil {
+		if x, ok := x.Geometry.(*Geometry_MultiLineString); ok {
+			return x.MultiLineString
+		}
+	}
+	return nil
+}
+
+func (x *Geometry) GetMultiPolygon() *MultiPolygon {
+	if x != nil {
+		if x, ok := x.Geometry.(*Geometry_MultiPolygon); ok {
+			return x.MultiPolygon
+		}
+	}
+	return nil
+}
+
+func (x *Geometry) GetGeometryCollection() *GeometryCollection {
+	if x != nil {
+		if x, ok := x.Geometry.(*Geometry_GeometryCollection); ok {
+			return x.GeometryCollection
+		}
+	}
+	return nil
+}
+
+type isGeometry_Geometry interface {
+	isGeometry_Geometry()
+}
+
+type Geometry_Point struct {
+	Point *Point `protobuf:"bytes,1,opt,name=point,proto3,oneof"`
+}
+
+type Geometry_LineString struct {
+	LineString *LineString `protobuf:"bytes,2,opt,name=line_string,json=lineString,proto3,oneof"`
+}
+
+type Geometry_Polygon struct {
+	Polygon *Polygon `protobuf:"bytes,3,opt,name=polygon,proto3,oneof"`
+}
+
+type Geometry_MultiPoint struct {
+	MultiPoint *MultiPoint `protobuf:"bytes,4,opt,name=multi_point,json=multiPoint,proto3,oneof"`
+}
+
+type Geometry_MultiLineString struct {
+	MultiLineString *MultiLineString `protobuf:"bytes,5,opt,name=multi_line_string,json=multiLineString,proto3,oneof"`
+}
+
+type Geometry_MultiPolygon struct {
+	MultiPolygon *MultiPolygon `protobuf:"bytes,6,opt,name=multi_polygon,json=multiPolygon,proto3,oneof"`
+}
+
+type Geometry_GeometryCollection struct {
+	GeometryCollection *GeometryCollection `protobuf:"bytes,7,opt,name=geometry_collection,json=geometryCollection,proto3,oneof"`
+}
+
+func (*Geometry_Point) isGeometry_Geometry() {}
+
+func (*Geometry_LineString) isGeometry_Geometry() {}
+
+func (*Geometry_Polygon) isGeometry_Geometry() {}
+
+func (*Geometry_MultiPoint) isGeometry_Geometry() {}
+
+func (*Geometry_MultiLineString) isGeometry_Geometry() {}
+
+func (*Geometry_MultiPolygon) isGeometry_Geometry() {}
+
+func (*Geometry_GeometryCollection) isGeometry_Geometry() {}
+
+var File_pmongo_geojson_proto protoreflect.FileDescriptor
+
+const file_pmongo_geojson_proto_rawDesc = "" +
+	"\n" +
+	"\x14pmongo/geojson.proto\x12\x06pmongo\"D\n" +
+	"\bPosition\x12\x1c\n" +
+	"\tlongitude\x18\x01 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\";\n" +
+	"\x05Point\x122\n" +
+	"\vcoordinates\x18\x01 \x01(\v2\x10.pmongo.PositionR\vcoordinates\"@\n" +
+	"\n" +
+	"LineString\x122\n" +
+	"\vcoordinates\x18\x01 \x03(\v2\x10.pmongo.PositionR\vcoordinates\"@\n" +
+	"\n" +
+	"LinearRing\x122\n" +
+	"\vcoordinates\x18\x01 \x03(\v2\x10.pmongo.PositionR\vcoordinates\"?\n" +
+	"\aPolygon\x124\n" +
+	"\vcoordinates\x18\x01 \x03(\v2\x12.pmongo.LinearRingR\vcoordinates\"@\n" +
+	"\n" +
+	"MultiPoint\x122\n" +
+	"\vcoordinates\x18\x01 \x03(\v2\x10.pmongo.PositionR\vcoordinates\"G\n" +
+	"\x0fMultiLineString\x124\n" +
+	"\vcoordinates\x18\x01 \x03(\v2\x12.pmongo.LineStringR\vcoordinates\"A\n" +
+	"\fMultiPolygon\x121\n" +
+	"\vcoordinates\x18\x01 \x03(\v2\x0f.pmongo.PolygonR\vcoordinates\"F\n" +
+	"\x12GeometryCollection\x120\n" +
+	"\n" +
+	"geometries\x18\x01 \x03(\v2\x10.pmongo.GeometryR\n" +
+	"geometries\"\xab\x03\n" +
+	"\bGeometry\x12%\n" +
+	"\x05point\x18\x01 \x01(\v2\r.pmongo.PointH\x00R\x05point\x125\n" +
+	"\vline_string\x18\x02 \x01(\v2\x12.pmongo.LineStringH\x00R\n" +
+	"lineString\x12+\n" +
+	"\apolygon\x18\x03 \x01(\v2\x0f.pmongo.PolygonH\x00R\apolygon\x125\n" +
+	"\vmulti_point\x18\x04 \x01(\v2\x12.pmongo.MultiPointH\x00R\n" +
+	"multiPoint\x12E\n" +
+	"\x11multi_line_string\x18\x05 \x01(\v2\x17.pmongo.MultiLineStringH\x00R\x0fmultiLineString\x12;\n" +
+	"\rmulti_polygon\x18\x06 \x01(\v2\x14.pmongo.MultiPolygonH\x00R\fmultiPolygon\x12M\n" +
+	"\x13geometry_collection\x18\a \x01(\v2\x1a.pmongo.GeometryCollectionH\x00R\x12geometryCollectionB\n" +
+	"\n" +
+	"\bgeometryB4Z2github.com/amsokol/mongo-go-driver-protobuf/pmongob\x06proto3"
+
+var (
+	file_pmongo_geojson_proto_rawDescOnce sync.Once
+	file_pmongo_geojson_proto_rawDescData []byte
+)
+
+func file_pmongo_geojson_proto_rawDescGZIP() []byte {
+	file_pmongo_geojson_proto_rawDescOnce.Do(func() {
+		file_pmongo_geojson_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pmongo_geojson_proto_rawDesc), len(file_pmongo_geojson_proto_rawDesc)))
+	})
+	return file_pmongo_geojson_proto_rawDescData
+}
+
+var file_pmongo_geojson_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_pmongo_geojson_proto_goTypes = []any{
+	(*Position)(nil),           // 0: pmongo.Position
+	(*Point)(nil),              // 1: pmongo.Point
+	(*LineString)(nil),         // 2: pmongo.LineString
+	(*LinearRing)(nil),         // 3: pmongo.LinearRing
+	(*Polygon)(nil),            // 4: pmongo.Polygon
+	(*MultiPoint)(nil),         // 5: pmongo.MultiPoint
+	(*MultiLineString)(nil),    // 6: pmongo.MultiLineString
+	(*MultiPolygon)(nil),       // 7: pmongo.MultiPolygon
+	(*GeometryCollection)(nil), // 8: pmongo.GeometryCollection
+	(*Geometry)(nil),           // 9: pmongo.Geometry
+}
+var file_pmongo_geojson_proto_depIdxs = []int32{
+	0,  // 0: pmongo.Point.coordinates:type_name -> pmongo.Position
+	0,  // 1: pmongo.LineString.coordinates:type_name -> pmongo.Position
+	0,  // 2: pmongo.LinearRing.coordinates:type_name -> pmongo.Position
+	3,  // 3: pmongo.Polygon.coordinates:type_name -> pmongo.LinearRing
+	0,  // 4: pmongo.MultiPoint.coordinates:type_name -> pmongo.Position
+	2,  // 5: pmongo.MultiLineString.coordinates:type_name -> pmongo.LineString
+	4,  // 6: pmongo.MultiPolygon.coordinates:type_name -> pmongo.Polygon
+	9,  // 7: pmongo.GeometryCollection.geometries:type_name -> pmongo.Geometry
+	1,  // 8: pmongo.Geometry.point:type_name -> pmongo.Point
+	2,  // 9: pmongo.Geometry.line_string:type_name -> pmongo.LineString
+	4,  // 10: pmongo.Geometry.polygon:type_name -> pmongo.Polygon
+	5,  // 11: pmongo.Geometry.multi_point:type_name -> pmongo.MultiPoint
+	6,  // 12: pmongo.Geometry.multi_line_string:type_name -> pmongo.MultiLineString
+	7,  // 13: pmongo.Geometry.multi_polygon:type_name -> pmongo.MultiPolygon
+	8,  // 14: pmongo.Geometry.geometry_collection:type_name -> pmongo.GeometryCollection
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_pmongo_geojson_proto_init() }
+func file_pmongo_geojson_proto_init() {
+	if File_pmongo_geojson_proto != nil {
+		return
+	}
+	file_pmongo_geojson_proto_msgTypes[9].OneofWrappers = []any{
+		(*Geometry_Point)(nil),
+		(*Geometry_LineString)(nil),
+		(*Geometry_Polygon)(nil),
+		(*Geometry_MultiPoint)(nil),
+		(*Geometry_MultiLineString)(nil),
+		(*Geometry_MultiPolygon)(nil),
+		(*Geometry_GeometryCollection)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pmongo_geojson_proto_rawDesc), len(file_pmongo_geojson_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pmongo_geojson_proto_goTypes,
+		DependencyIndexes: file_pmongo_geojson_proto_depIdxs,
+		MessageInfos:      file_pmongo_geojson_proto_msgTypes,
+	}.Build()
+	File_pmongo_geojson_proto = out.File
+	file_pmongo_geojson_proto_goTypes = nil
+	file_pmongo_geojson_proto_depIdxs = nil
+}
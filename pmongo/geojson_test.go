@@ -0,0 +1,74 @@
+package pmongo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGeoJSONValidate(t *testing.T) {
+	ring := func(ps ...*Position) *LinearRing {
+		return &LinearRing{Coordinates: ps}
+	}
+	square := ring(NewPosition(0, 0), NewPosition(0, 1), NewPosition(1, 1), NewPosition(1, 0), NewPosition(0, 0))
+	hole := ring(NewPosition(0.2, 0.2), NewPosition(0.2, 0.8), NewPosition(0.8, 0.8), NewPosition(0.2, 0.2))
+	polygon := &Polygon{Coordinates: []*LinearRing{square, hole}}
+	collection, _ := NewGeometry(&GeometryCollection{Geometries: []*Geometry{{}}})
+	invalidLine, _ := NewGeometry(&LineString{Coordinates: square.Coordinates[:1]})
+
+	tests := []struct {
+		name string
+		in   GeoJSONGeometry
+		path string
+	}{
+		{name: "point", in: NewPoint(-180, 90)},
+		{name: "nil point", in: (*Point)(nil)},
+		{name: "longitude out of range", in: NewPoint(-180.5, 0), path: "coordinates"},
+		{name: "latitude is NaN", in: NewPoint(0, math.NaN()), path: "coordinates"},
+		{name: "line string", in: &LineString{Coordinates: square.Coordinates}},
+		{name: "short line string", in: &LineString{Coordinates: square.Coordinates[:1]}, path: "coordinates"},
+		{name: "polygon with hole", in: polygon},
+		{name: "polygon without rings", in: &Polygon{}, path: "coordinates"},
+		{name: "open ring", in: &Polygon{Coordinates: []*LinearRing{square, ring(hole.Coordinates[:3]...)}}, path: "coordinates.1"},
+		{name: "short ring", in: &Polygon{Coordinates: []*LinearRing{ring(NewPosition(0, 0), NewPosition(1, 1), NewPosition(0, 0))}}, path: "coordinates.0"},
+		{name: "invalid ring position", in: &Polygon{Coordinates: []*LinearRing{ring(square.Coordinates[0], NewPosition(0, 100), NewPosition(1, 1), square.Coordinates[0])}}, path: "coordinates.0.1"},
+		{name: "multi point", in: &MultiPoint{Coordinates: []*Position{NewPosition(1, 1), nil}}, path: "coordinates.1"},
+		{name: "multi line string", in: &MultiLineString{Coordinates: []*LineString{{Coordinates: square.Coordinates}, {}}}, path: "coordinates.1"},
+		{name: "multi polygon", in: &MultiPolygon{Coordinates: []*Polygon{polygon, {}}}, path: "coordinates.1"},
+		{name: "unset geometry in collection", in: collection, path: "geometries.0"},
+		{name: "nil geometry in collection", in: &GeometryCollection{Geometries: []*Geometry{nil}}, path: "geometries.0"},
+		{name: "invalid geometry in collection", in: &GeometryCollection{Geometries: []*Geometry{invalidLine}}, path: "geometries.0.coordinates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.in.Validate()
+			if tt.path == "" {
+				if err != nil {
+					t.Errorf("Validate error = %v", err)
+				}
+				return
+			}
+			var e *InvalidGeoJSONError
+			if !errors.As(err, &e) || e.Path != tt.path || e.Type != tt.in.GeoJSONType() {
+				t.Errorf("Validate error = %v, *InvalidGeoJSONError having path %q expected", err, tt.path)
+				return
+			}
+		})
+	}
+
+	t.Run("geometry", func(t *testing.T) {
+		g, err := NewGeometry(polygon)
+		if err != nil {
+			t.Errorf("NewGeometry error = %v", err)
+			return
+		}
+		if g.GetPolygon() != polygon || g.GetGeoJSONGeometry() != GeoJSONGeometry(polygon) || g.GeoJSONType() != GeoJSONPolygon {
+			t.Errorf("failed: geometry=%v", g)
+			return
+		}
+		if (&Geometry{}).GeoJSONType() != "" {
+			t.Errorf("failed: type of unset geometry must be empty")
+			return
+		}
+	})
+}
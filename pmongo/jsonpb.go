@@ -2,26 +2,341 @@ package pmongo
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/jsonpb"
-	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // MarshalJSONPB marshals ObjectId to JSONPB string
 func (o *ObjectId) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
-	s, err := m.MarshalToString(&wrappers.StringValue{Value: o.Value})
+	s, err := m.MarshalToString(&wrapperspb.StringValue{Value: o.Value})
 	if err != nil {
 		return nil, err
 	}
 	return []byte(s), nil
 }
 
-// UnmarshalJSONPB unmarshal JSONPB string to ObjectId
+// UnmarshalJSONPB unmarshal JSONPB string to ObjectId.
+// Empty string written by MarshalJSONPB for empty ObjectId is unmarshalled as empty ObjectId.
+// It fails with *ErrInvalidObjectId if string is not valid object ID.
 func (o *ObjectId) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
-	var id wrappers.StringValue
-	if err := m.Unmarshal(bytes.NewReader(data), &id); err != nil {
+	var s wrapperspb.StringValue
+	if err := m.Unmarshal(bytes.NewReader(data), &s); err != nil {
+		return err
+	}
+	if s.Value == "" {
+		o.Value = ""
+		return nil
+	}
+	id, err := ObjectIdFromHex(s.Value)
+	if err != nil {
 		return err
 	}
 	o.Value = id.Value
 	return nil
 }
+
+// MarshalJSONPB marshals BinaryObjectId to JSONPB hex string the same way as ObjectId.
+// Empty BinaryObjectId is marshalled as empty string.
+func (o *BinaryObjectId) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	if len(o.GetValue()) == 0 {
+		return (&ObjectId{}).MarshalJSONPB(m)
+	}
+	id, err := o.GetObjectID()
+	if err != nil {
+		return nil, err
+	}
+	return NewObjectId(id).MarshalJSONPB(m)
+}
+
+// UnmarshalJSONPB unmarshal JSONPB hex string to BinaryObjectId.
+// Empty string is unmarshalled as empty BinaryObjectId.
+func (o *BinaryObjectId) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var hex ObjectId
+	if err := hex.UnmarshalJSONPB(m, data); err != nil {
+		return err
+	}
+	if hex.Value == "" {
+		o.Value = nil
+		return nil
+	}
+	id, err := hex.GetObjectID()
+	if err != nil {
+		return err
+	}
+	o.Value = id[:]
+	return nil
+}
+
+// MarshalJSONPB marshals Decimal128 to JSONPB decimal string, e.g. "123.45"
+func (d *Decimal128) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	s, err := m.MarshalToString(&wrapperspb.StringValue{Value: d.GetDecimal128().String()})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalJSONPB unmarshal JSONPB decimal string or number to Decimal128
+func (d *Decimal128) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	s := string(bytes.TrimSpace(data))
+	if !strings.HasPrefix(s, `"`) {
+		// JSON number is kept as written without conversion to float64
+		return d.parse(s)
+	}
+	var v wrapperspb.StringValue
+	if err := m.Unmarshal(bytes.NewReader(data), &v); err != nil {
+		return err
+	}
+	return d.parse(v.Value)
+}
+
+// parse sets Decimal128 value from decimal string
+func (d *Decimal128) parse(s string) error {
+	v, err := ParseDecimal128(s)
+	if err != nil {
+		return err
+	}
+	d.High, d.Low = v.High, v.Low
+	return nil
+}
+
+// MarshalJSONPB marshals UUID to JSONPB string in canonical text form
+func (u *UUID) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	text, err := u.Text()
+	if err != nil {
+		return nil, err
+	}
+	s, err := m.MarshalToString(&wrapperspb.StringValue{Value: text})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalJSONPB unmarshal JSONPB string in canonical text form to UUID.
+// It fails with *InvalidUUIDError if string is not valid UUID.
+func (u *UUID) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var s wrapperspb.StringValue
+	if err := m.Unmarshal(bytes.NewReader(data), &s); err != nil {
+		return err
+	}
+	v, err := ParseUUID(s.Value)
+	if err != nil {
+		return err
+	}
+	u.Value = v.Value
+	return nil
+}
+
+// Extended JSON representations of BSON types, see
+// https://github.com/mongodb/specifications/blob/master/source/extended-json.rst
+type (
+	extJSONObjectID struct {
+		OID string `json:"$oid"`
+	}
+	extJSONTimestampValue struct {
+		T uint32 `json:"t"`
+		I uint32 `json:"i"`
+	}
+	extJSONTimestamp struct {
+		Timestamp *extJSONTimestampValue `json:"$timestamp"`
+	}
+	extJSONRegexValue struct {
+		Pattern string `json:"pattern"`
+		Options string `json:"options"`
+	}
+	extJSONRegex struct {
+		Regex *extJSONRegexValue `json:"$regularExpression"`
+	}
+	extJSONJavaScript struct {
+		Code *string `json:"$code"`
+	}
+	extJSONDBRef struct {
+		Ref *string          `json:"$ref"`
+		ID  *extJSONObjectID `json:"$id"`
+		DB  string           `json:"$db,omitempty"`
+	}
+	extJSONDBPointer struct {
+		Pointer *extJSONDBRef `json:"$dbPointer"`
+	}
+	extJSONMinKey struct {
+		MinKey int `json:"$minKey"`
+	}
+	extJSONMaxKey struct {
+		MaxKey int `json:"$maxKey"`
+	}
+	extJSONBinaryValue struct {
+		Base64  []byte `json:"base64"`
+		Subtype string `json:"subtype"`
+	}
+	extJSONBinary struct {
+		Binary *extJSONBinaryValue `json:"$binary"`
+	}
+)
+
+// unmarshalExtJSON unmarshals Extended JSON of BSON type to v and fails if mandatory key is missing
+func unmarshalExtJSON(data []byte, v interface{}, typ string, ok func() bool) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid Extended JSON %s: %v", typ, err)
+	}
+	if !ok() {
+		return fmt.Errorf("invalid Extended JSON %s: %s", typ, data)
+	}
+	return nil
+}
+
+// newExtJSONObjectID returns Extended JSON of object ID or *ErrInvalidObjectId if it is not valid
+func newExtJSONObjectID(o *ObjectId) (*extJSONObjectID, error) {
+	id, err := o.GetObjectID()
+	if err != nil {
+		return nil, err
+	}
+	return &extJSONObjectID{OID: id.Hex()}, nil
+}
+
+// MarshalJSONPB marshals Timestamp to Extended JSON {"$timestamp": {"t": 1, "i": 2}}
+func (ts *Timestamp) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	return json.Marshal(extJSONTimestamp{Timestamp: &extJSONTimestampValue{T: ts.T, I: ts.I}})
+}
+
+// UnmarshalJSONPB unmarshal Extended JSON {"$timestamp": {"t": 1, "i": 2}} to Timestamp
+func (ts *Timestamp) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var v extJSONTimestamp
+	if err := unmarshalExtJSON(data, &v, "$timestamp", func() bool { return v.Timestamp != nil }); err != nil {
+		return err
+	}
+	ts.T, ts.I = v.Timestamp.T, v.Timestamp.I
+	return nil
+}
+
+// MarshalJSONPB marshals Regex to Extended JSON {"$regularExpression": {"pattern": "...", "options": "..."}}
+func (r *Regex) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	return json.Marshal(extJSONRegex{Regex: &extJSONRegexValue{Pattern: r.Pattern, Options: r.Options}})
+}
+
+// UnmarshalJSONPB unmarshal Extended JSON {"$regularExpression": {"pattern": "...", "options": "..."}} to Regex
+func (r *Regex) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var v extJSONRegex
+	if err := unmarshalExtJSON(data, &v, "$regularExpression", func() bool { return v.Regex != nil }); err != nil {
+		return err
+	}
+	r.Pattern, r.Options = v.Regex.Pattern, v.Regex.Options
+	return nil
+}
+
+// MarshalJSONPB marshals JavaScript to Extended JSON {"$code": "..."}
+func (js *JavaScript) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	return json.Marshal(extJSONJavaScript{Code: &js.Code})
+}
+
+// UnmarshalJSONPB unmarshal Extended JSON {"$code": "..."} to JavaScript
+func (js *JavaScript) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var v extJSONJavaScript
+	if err := unmarshalExtJSON(data, &v, "$code", func() bool { return v.Code != nil }); err != nil {
+		return err
+	}
+	js.Code = *v.Code
+	return nil
+}
+
+// MarshalJSONPB marshals DBPointer to Extended JSON {"$dbPointer": {"$ref": "...", "$id": {"$oid": "..."}}}
+func (p *DBPointer) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	id, err := newExtJSONObjectID(p.Id)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(extJSONDBPointer{Pointer: &extJSONDBRef{Ref: &p.Db, ID: id}})
+}
+
+// UnmarshalJSONPB unmarshal Extended JSON {"$dbPointer": {"$ref": "...", "$id": {"$oid": "..."}}} to DBPointer
+func (p *DBPointer) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var v extJSONDBPointer
+	if err := unmarshalExtJSON(data, &v, "$dbPointer", func() bool {
+		return v.Pointer != nil && v.Pointer.Ref != nil && v.Pointer.ID != nil
+	}); err != nil {
+		return err
+	}
+	id, err := ObjectIdFromHex(v.Pointer.ID.OID)
+	if err != nil {
+		return err
+	}
+	p.Db, p.Id = *v.Pointer.Ref, id
+	return nil
+}
+
+// MarshalJSONPB marshals DBRef to Extended JSON {"$ref": "...", "$id": {"$oid": "..."}, "$db": "..."}
+func (r *DBRef) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	id, err := newExtJSONObjectID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(extJSONDBRef{Ref: &r.Ref, ID: id, DB: r.Db})
+}
+
+// UnmarshalJSONPB unmarshal Extended JSON {"$ref": "...", "$id": {"$oid": "..."}, "$db": "..."} to DBRef
+func (r *DBRef) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var v extJSONDBRef
+	if err := unmarshalExtJSON(data, &v, "DBRef", func() bool { return v.Ref != nil && v.ID != nil }); err != nil {
+		return err
+	}
+	id, err := ObjectIdFromHex(v.ID.OID)
+	if err != nil {
+		return err
+	}
+	r.Ref, r.Id, r.Db = *v.Ref, id, v.DB
+	return nil
+}
+
+// MarshalJSONPB marshals MinKey to Extended JSON {"$minKey": 1}
+func (*MinKey) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	return json.Marshal(extJSONMinKey{MinKey: 1})
+}
+
+// UnmarshalJSONPB unmarshal Extended JSON {"$minKey": 1} to MinKey
+func (*MinKey) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var v extJSONMinKey
+	return unmarshalExtJSON(data, &v, "$minKey", func() bool { return v.MinKey == 1 })
+}
+
+// MarshalJSONPB marshals MaxKey to Extended JSON {"$maxKey": 1}
+func (*MaxKey) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	return json.Marshal(extJSONMaxKey{MaxKey: 1})
+}
+
+// UnmarshalJSONPB unmarshal Extended JSON {"$maxKey": 1} to MaxKey
+func (*MaxKey) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var v extJSONMaxKey
+	return unmarshalExtJSON(data, &v, "$maxKey", func() bool { return v.MaxKey == 1 })
+}
+
+// MarshalJSONPB marshals Binary to Extended JSON {"$binary": {"base64": "...", "subtype": "00"}}
+func (b *Binary) MarshalJSONPB(m *jsonpb.Marshaler) ([]byte, error) {
+	bin, err := b.GetBinary()
+	if err != nil {
+		return nil, err
+	}
+	if bin.Data == nil {
+		// Empty binary is "" instead of null
+		bin.Data = []byte{}
+	}
+	return json.Marshal(extJSONBinary{Binary: &extJSONBinaryValue{Base64: bin.Data, Subtype: fmt.Sprintf("%02x", bin.Subtype)}})
+}
+
+// UnmarshalJSONPB unmarshal Extended JSON {"$binary": {"base64": "...", "subtype": "00"}} to Binary
+func (b *Binary) UnmarshalJSONPB(m *jsonpb.Unmarshaler, data []byte) error {
+	var v extJSONBinary
+	if err := unmarshalExtJSON(data, &v, "$binary", func() bool { return v.Binary != nil }); err != nil {
+		return err
+	}
+	subtype, err := strconv.ParseUint(v.Binary.Subtype, 16, 8)
+	if err != nil || len(v.Binary.Subtype) == 0 || len(v.Binary.Subtype) > 2 {
+		return fmt.Errorf("invalid Extended JSON $binary subtype %q: must be 1 or 2 hex characters", v.Binary.Subtype)
+	}
+	b.Subtype, b.Data = uint32(subtype), v.Binary.Base64
+	return nil
+}
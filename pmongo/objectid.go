@@ -1,15 +1,156 @@
 package pmongo
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// ErrInvalidObjectId is error of value which is not valid MongoDB ObjectID
+type ErrInvalidObjectId struct {
+	// Value is invalid value, binary value is represented as hex string
+	Value string
+}
+
+// Error returns error message
+func (e *ErrInvalidObjectId) Error() string {
+	return fmt.Sprintf("invalid ObjectId %q: must be 12 bytes or 24 hex characters", e.Value)
+}
+
 // NewObjectId creates proto ObjectId from MongoDB ObjectID
 func NewObjectId(id primitive.ObjectID) *ObjectId {
 	return &ObjectId{Value: id.Hex()}
 }
 
-// GetObjectID returns MongoDB object ID
+// NewObjectIdNow creates proto ObjectId having new unique MongoDB ObjectID
+func NewObjectIdNow() *ObjectId {
+	return NewObjectId(primitive.NewObjectID())
+}
+
+// ObjectIdFromHex creates proto ObjectId from hex string.
+// It fails if string is not 24 hex characters. Value of ObjectId is lower case hex string.
+func ObjectIdFromHex(s string) (*ObjectId, error) {
+	id, err := primitive.ObjectIDFromHex(s)
+	if err != nil {
+		return nil, &ErrInvalidObjectId{Value: s}
+	}
+	return NewObjectId(id), nil
+}
+
+// GetObjectID returns MongoDB object ID or *ErrInvalidObjectId if value is not valid
 func (o *ObjectId) GetObjectID() (primitive.ObjectID, error) {
-	return primitive.ObjectIDFromHex(o.Value)
+	id, err := primitive.ObjectIDFromHex(o.GetValue())
+	if err != nil {
+		return id, &ErrInvalidObjectId{Value: o.GetValue()}
+	}
+	return id, nil
+}
+
+// Validate returns *ErrInvalidObjectId if value is not valid object ID
+func (o *ObjectId) Validate() error {
+	if o == nil {
+		return nil
+	}
+	_, err := o.GetObjectID()
+	return err
+}
+
+// Time returns creation time of object ID with second precision
+func (o *ObjectId) Time() (time.Time, error) {
+	id, err := o.GetObjectID()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(binary.BigEndian.Uint32(id[0:4])), 0).UTC(), nil
+}
+
+// Timestamp returns creation time of object ID as proto Timestamp
+func (o *ObjectId) Timestamp() (*timestamppb.Timestamp, error) {
+	t, err := o.Time()
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
+
+// IsZero returns true if ObjectId is nil, empty or all zeros object ID
+func (o *ObjectId) IsZero() bool {
+	if o.GetValue() == "" {
+		return true
+	}
+	id, err := o.GetObjectID()
+	return err == nil && id.IsZero()
+}
+
+// IsValid returns true if ObjectId value is 24 hex characters
+func (o *ObjectId) IsValid() bool {
+	_, err := o.GetObjectID()
+	return err == nil
+}
+
+// Equal returns true if both ObjectId have the same object ID regardless of hex case.
+// Invalid values are equal if their strings are equal.
+func (o *ObjectId) Equal(other *ObjectId) bool {
+	id1, err1 := o.GetObjectID()
+	id2, err2 := other.GetObjectID()
+	if err1 != nil || err2 != nil {
+		return o.GetValue() == other.GetValue()
+	}
+	return id1 == id2
+}
+
+// Compare compares object IDs byte by byte, i.e. by creation time first, the same way as MongoDB does.
+// It returns 0 if o == other, -1 if o < other and +1 if o > other.
+// Nil, empty and invalid ObjectId are ordered as all zeros object ID.
+func (o *ObjectId) Compare(other *ObjectId) int {
+	id1, _ := o.GetObjectID()
+	id2, _ := other.GetObjectID()
+	return bytes.Compare(id1[:], id2[:])
+}
+
+// ToBinary converts ObjectId to BinaryObjectId
+func (o *ObjectId) ToBinary() (*BinaryObjectId, error) {
+	id, err := o.GetObjectID()
+	if err != nil {
+		return nil, err
+	}
+	return NewBinaryObjectId(id), nil
+}
+
+// NewBinaryObjectId creates proto BinaryObjectId from MongoDB ObjectID
+func NewBinaryObjectId(id primitive.ObjectID) *BinaryObjectId {
+	return &BinaryObjectId{Value: id[:]}
+}
+
+// GetObjectID returns MongoDB object ID or *ErrInvalidObjectId if value is not 12 bytes
+func (o *BinaryObjectId) GetObjectID() (primitive.ObjectID, error) {
+	var id primitive.ObjectID
+	if len(o.GetValue()) != len(id) {
+		return id, &ErrInvalidObjectId{Value: hex.EncodeToString(o.GetValue())}
+	}
+	copy(id[:], o.Value)
+	return id, nil
+}
+
+// Validate returns *ErrInvalidObjectId if value is not valid object ID
+func (o *BinaryObjectId) Validate() error {
+	if o == nil {
+		return nil
+	}
+	_, err := o.GetObjectID()
+	return err
+}
+
+// ToHex converts BinaryObjectId to ObjectId
+func (o *BinaryObjectId) ToHex() (*ObjectId, error) {
+	id, err := o.GetObjectID()
+	if err != nil {
+		return nil, err
+	}
+	return NewObjectId(id), nil
 }
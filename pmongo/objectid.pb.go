@@ -1,78 +1,170 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: objectid.proto
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pmongo/objectid.proto
 
 package pmongo
 
 import (
-	fmt "fmt"
-	proto "github.com/golang/protobuf/proto"
-	math "math"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
 type ObjectId struct {
-	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectId) Reset() {
+	*x = ObjectId{}
+	mi := &file_pmongo_objectid_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectId) ProtoMessage() {}
+
+func (x *ObjectId) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_objectid_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *ObjectId) Reset()         { *m = ObjectId{} }
-func (m *ObjectId) String() string { return proto.CompactTextString(m) }
-func (*ObjectId) ProtoMessage()    {}
+// Deprecated: Use ObjectId.ProtoReflect.Descriptor instead.
 func (*ObjectId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311fc7ad12a77133, []int{0}
+	return file_pmongo_objectid_proto_rawDescGZIP(), []int{0}
 }
 
-func (m *ObjectId) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ObjectId.Unmarshal(m, b)
+func (x *ObjectId) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
 }
-func (m *ObjectId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ObjectId.Marshal(b, m, deterministic)
+
+// BinaryObjectId is MongoDB ObjectID kept as raw 12 bytes instead of hex string
+type BinaryObjectId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
-func (m *ObjectId) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ObjectId.Merge(m, src)
+
+func (x *BinaryObjectId) Reset() {
+	*x = BinaryObjectId{}
+	mi := &file_pmongo_objectid_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
-func (m *ObjectId) XXX_Size() int {
-	return xxx_messageInfo_ObjectId.Size(m)
+
+func (x *BinaryObjectId) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *ObjectId) XXX_DiscardUnknown() {
-	xxx_messageInfo_ObjectId.DiscardUnknown(m)
+
+func (*BinaryObjectId) ProtoMessage() {}
+
+func (x *BinaryObjectId) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_objectid_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_ObjectId proto.InternalMessageInfo
+// Deprecated: Use BinaryObjectId.ProtoReflect.Descriptor instead.
+func (*BinaryObjectId) Descriptor() ([]byte, []int) {
+	return file_pmongo_objectid_proto_rawDescGZIP(), []int{1}
+}
 
-func (m *ObjectId) GetValue() string {
-	if m != nil {
-		return m.Value
+func (x *BinaryObjectId) GetValue() []byte {
+	if x != nil {
+		return x.Value
 	}
-	return ""
+	return nil
 }
 
-func init() {
-	proto.RegisterType((*ObjectId)(nil), "pmongo.ObjectId")
+var File_pmongo_objectid_proto protoreflect.FileDescriptor
+
+const file_pmongo_objectid_proto_rawDesc = "" +
+	"\n" +
+	"\x15pmongo/objectid.proto\x12\x06pmongo\" \n" +
+	"\bObjectId\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"&\n" +
+	"\x0eBinaryObjectId\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05valueB4Z2github.com/amsokol/mongo-go-driver-protobuf/pmongob\x06proto3"
+
+var (
+	file_pmongo_objectid_proto_rawDescOnce sync.Once
+	file_pmongo_objectid_proto_rawDescData []byte
+)
+
+func file_pmongo_objectid_proto_rawDescGZIP() []byte {
+	file_pmongo_objectid_proto_rawDescOnce.Do(func() {
+		file_pmongo_objectid_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pmongo_objectid_proto_rawDesc), len(file_pmongo_objectid_proto_rawDesc)))
+	})
+	return file_pmongo_objectid_proto_rawDescData
 }
 
-func init() { proto.RegisterFile("objectid.proto", fileDescriptor_311fc7ad12a77133) }
+var file_pmongo_objectid_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pmongo_objectid_proto_goTypes = []any{
+	(*ObjectId)(nil),       // 0: pmongo.ObjectId
+	(*BinaryObjectId)(nil), // 1: pmongo.BinaryObjectId
+}
+var file_pmongo_objectid_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
 
-var fileDescriptor_311fc7ad12a77133 = []byte{
-	// 124 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcb, 0x4f, 0xca, 0x4a,
-	0x4d, 0x2e, 0xc9, 0x4c, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2b, 0xc8, 0xcd, 0xcf,
-	0x4b, 0xcf, 0x57, 0x52, 0xe0, 0xe2, 0xf0, 0x07, 0xcb, 0x78, 0xa6, 0x08, 0x89, 0x70, 0xb1, 0x96,
-	0x25, 0xe6, 0x94, 0xa6, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x06, 0x41, 0x38, 0x4e, 0x26, 0x51,
-	0x46, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0x89, 0xb9, 0xc5, 0xf9,
-	0xd9, 0xf9, 0x39, 0xfa, 0x60, 0xdd, 0xba, 0xe9, 0xf9, 0xba, 0x29, 0x45, 0x99, 0x65, 0xa9, 0x45,
-	0xba, 0x60, 0x53, 0x93, 0x4a, 0xd3, 0xf4, 0x21, 0xe6, 0x26, 0xb1, 0x81, 0x05, 0x8c, 0x01, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xbe, 0x64, 0x87, 0x94, 0x78, 0x00, 0x00, 0x00,
+func init() { file_pmongo_objectid_proto_init() }
+func file_pmongo_objectid_proto_init() {
+	if File_pmongo_objectid_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pmongo_objectid_proto_rawDesc), len(file_pmongo_objectid_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pmongo_objectid_proto_goTypes,
+		DependencyIndexes: file_pmongo_objectid_proto_depIdxs,
+		MessageInfos:      file_pmongo_objectid_proto_msgTypes,
+	}.Build()
+	File_pmongo_objectid_proto = out.File
+	file_pmongo_objectid_proto_goTypes = nil
+	file_pmongo_objectid_proto_depIdxs = nil
 }
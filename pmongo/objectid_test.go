@@ -0,0 +1,192 @@
+package pmongo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestObjectId(t *testing.T) {
+	t.Run("new object id now", func(t *testing.T) {
+		before := time.Now().Add(-time.Second)
+		id := NewObjectIdNow()
+		if !id.IsValid() || id.IsZero() {
+			t.Errorf("failed: id=%v", id)
+			return
+		}
+		tm, err := id.Time()
+		if err != nil {
+			t.Errorf("ObjectId.Time error = %v", err)
+			return
+		}
+		if tm.Before(before.Truncate(time.Second)) || tm.After(time.Now()) {
+			t.Errorf("failed: time=%v", tm)
+			return
+		}
+		if NewObjectIdNow().Equal(id) {
+			t.Errorf("failed: object IDs are not unique")
+			return
+		}
+	})
+
+	t.Run("timestamp", func(t *testing.T) {
+		id, err := ObjectIdFromHex("5c53a8ce0000000000000000")
+		if err != nil {
+			t.Errorf("ObjectIdFromHex error = %v", err)
+			return
+		}
+		expected := time.Date(2019, 2, 1, 2, 2, 54, 0, time.UTC)
+		tm, err := id.Time()
+		if err != nil {
+			t.Errorf("ObjectId.Time error = %v", err)
+			return
+		}
+		if !tm.Equal(expected) {
+			t.Errorf("failed: time=%v, expected=%v", tm, expected)
+			return
+		}
+		ts, err := id.Timestamp()
+		if err != nil {
+			t.Errorf("ObjectId.Timestamp error = %v", err)
+			return
+		}
+		if !ts.AsTime().Equal(expected) {
+			t.Errorf("failed: timestamp=%v, expected=%v", ts, expected)
+			return
+		}
+		if _, err = (&ObjectId{Value: "bad"}).Timestamp(); err == nil {
+			t.Errorf("ObjectId.Timestamp error expected for invalid value")
+			return
+		}
+	})
+
+	t.Run("from hex", func(t *testing.T) {
+		hex := primitive.NewObjectID().Hex()
+		id, err := ObjectIdFromHex(strings.ToUpper(hex))
+		if err != nil {
+			t.Errorf("ObjectIdFromHex error = %v", err)
+			return
+		}
+		if id.Value != hex {
+			t.Errorf("failed: value=%q, expected=%q", id.Value, hex)
+			return
+		}
+		for _, s := range []string{"", "5c53a8ce", "5c53a8ce00000000000000zz", "5c53a8ce000000000000000000"} {
+			if _, err = ObjectIdFromHex(s); err == nil {
+				t.Errorf("ObjectIdFromHex error expected for %q", s)
+				return
+			}
+		}
+	})
+
+	t.Run("is zero/is valid", func(t *testing.T) {
+		tests := []struct {
+			id    *ObjectId
+			zero  bool
+			valid bool
+		}{
+			{id: nil, zero: true, valid: false},
+			{id: &ObjectId{}, zero: true, valid: false},
+			{id: NewObjectId(primitive.NilObjectID), zero: true, valid: true},
+			{id: NewObjectIdNow(), zero: false, valid: true},
+			{id: &ObjectId{Value: "qwerty"}, zero: false, valid: false},
+		}
+		for _, tt := range tests {
+			if tt.id.IsZero() != tt.zero || tt.id.IsValid() != tt.valid {
+				t.Errorf("failed: id=%v, IsZero=%v, IsValid=%v", tt.id, tt.id.IsZero(), tt.id.IsValid())
+				return
+			}
+		}
+	})
+
+	t.Run("equal/compare", func(t *testing.T) {
+		a, _ := ObjectIdFromHex("5c53a8ce0000000000000001")
+		b, _ := ObjectIdFromHex("5c53a8cf0000000000000000")
+		upper := &ObjectId{Value: strings.ToUpper(a.Value)}
+
+		if !a.Equal(upper) || a.Compare(upper) != 0 {
+			t.Errorf("failed: %v and %v must be equal", a, upper)
+			return
+		}
+		if a.Equal(b) || a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("failed: %v must be less than %v", a, b)
+			return
+		}
+		if !(&ObjectId{Value: "bad"}).Equal(&ObjectId{Value: "bad"}) || a.Equal(nil) {
+			t.Errorf("failed: invalid values are compared as strings")
+			return
+		}
+		if (*ObjectId)(nil).Compare(a) != -1 {
+			t.Errorf("failed: nil must be less than %v", a)
+			return
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		if err := NewObjectIdNow().Validate(); err != nil {
+			t.Errorf("ObjectId.Validate error = %v", err)
+			return
+		}
+		if err := (*ObjectId)(nil).Validate(); err != nil {
+			t.Errorf("ObjectId.Validate error = %v for nil", err)
+			return
+		}
+		var e *ErrInvalidObjectId
+		if err := (&ObjectId{Value: "qwerty"}).Validate(); !errors.As(err, &e) || e.Value != "qwerty" {
+			t.Errorf("ObjectId.Validate error = %v, *ErrInvalidObjectId expected", err)
+			return
+		}
+		if err := (&BinaryObjectId{Value: []byte{1, 2}}).Validate(); !errors.As(err, &e) || e.Value != "0102" {
+			t.Errorf("BinaryObjectId.Validate error = %v, *ErrInvalidObjectId expected", err)
+			return
+		}
+	})
+
+	t.Run("unmarshal-jsonpb", func(t *testing.T) {
+		hex := primitive.NewObjectID().Hex()
+		var id ObjectId
+		if err := jsonpb.UnmarshalString(`"`+hex+`"`, &id); err != nil || id.Value != hex {
+			t.Errorf("jsonpb.UnmarshalString error = %v, id=%v", err, &id)
+			return
+		}
+		var e *ErrInvalidObjectId
+		if err := jsonpb.UnmarshalString(`"qwerty"`, &id); !errors.As(err, &e) || e.Value != "qwerty" {
+			t.Errorf("jsonpb.UnmarshalString error = %v, *ErrInvalidObjectId expected", err)
+			return
+		}
+		var bid BinaryObjectId
+		if err := jsonpb.UnmarshalString(`"qwerty"`, &bid); !errors.As(err, &e) {
+			t.Errorf("jsonpb.UnmarshalString error = %v, *ErrInvalidObjectId expected", err)
+			return
+		}
+	})
+
+	t.Run("empty jsonpb round trip", func(t *testing.T) {
+		var m jsonpb.Marshaler
+		for _, tt := range []struct {
+			in, out proto.Message
+		}{
+			{in: &ObjectId{}, out: NewObjectId(primitive.NewObjectID())},
+			{in: &BinaryObjectId{}, out: NewBinaryObjectId(primitive.NewObjectID())},
+		} {
+			s, err := m.MarshalToString(tt.in)
+			if err != nil || s != `""` {
+				t.Errorf("jsonpb.Marshaler.MarshalToString error = %v, s=%v", err, s)
+				return
+			}
+			if err = jsonpb.UnmarshalString(s, tt.out); err != nil {
+				t.Errorf("jsonpb.UnmarshalString error = %v", err)
+				return
+			}
+			if !proto.Equal(tt.in, tt.out) {
+				t.Errorf("failed: expected=%v, value=%v", tt.in, tt.out)
+				return
+			}
+		}
+	})
+}
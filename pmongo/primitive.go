@@ -0,0 +1,91 @@
+package pmongo
+
+import (
+	"fmt"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewTimestamp creates proto Timestamp from BSON timestamp
+func NewTimestamp(ts primitive.Timestamp) *Timestamp {
+	return &Timestamp{T: ts.T, I: ts.I}
+}
+
+// GetTimestamp returns BSON timestamp
+func (ts *Timestamp) GetTimestamp() primitive.Timestamp {
+	return primitive.Timestamp{T: ts.GetT(), I: ts.GetI()}
+}
+
+// NewRegex creates proto Regex from BSON regular expression
+func NewRegex(r primitive.Regex) *Regex {
+	return &Regex{Pattern: r.Pattern, Options: r.Options}
+}
+
+// GetRegex returns BSON regular expression
+func (r *Regex) GetRegex() primitive.Regex {
+	return primitive.Regex{Pattern: r.GetPattern(), Options: r.GetOptions()}
+}
+
+// NewJavaScript creates proto JavaScript from BSON JavaScript code
+func NewJavaScript(code primitive.JavaScript) *JavaScript {
+	return &JavaScript{Code: string(code)}
+}
+
+// GetJavaScript returns BSON JavaScript code
+func (js *JavaScript) GetJavaScript() primitive.JavaScript {
+	return primitive.JavaScript(js.GetCode())
+}
+
+// NewDBPointer creates proto DBPointer from BSON DBPointer
+func NewDBPointer(p primitive.DBPointer) *DBPointer {
+	return &DBPointer{Db: p.DB, Id: NewObjectId(p.Pointer)}
+}
+
+// GetDBPointer returns BSON DBPointer or *ErrInvalidObjectId if object ID is not valid
+func (p *DBPointer) GetDBPointer() (primitive.DBPointer, error) {
+	id, err := p.GetId().GetObjectID()
+	if err != nil {
+		return primitive.DBPointer{}, err
+	}
+	return primitive.DBPointer{DB: p.GetDb(), Pointer: id}, nil
+}
+
+// NewDBRef creates proto DBRef referencing document by collection, object ID and optional database
+func NewDBRef(ref string, id primitive.ObjectID, db string) *DBRef {
+	return &DBRef{Ref: ref, Id: NewObjectId(id), Db: db}
+}
+
+// Validate returns error if collection is empty or object ID is not valid
+func (r *DBRef) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.Ref == "" {
+		return fmt.Errorf("invalid DBRef: collection is empty")
+	}
+	_, err := r.GetId().GetObjectID()
+	return err
+}
+
+// NewBinary creates proto Binary from BSON binary
+func NewBinary(b primitive.Binary) *Binary {
+	return &Binary{Subtype: uint32(b.Subtype), Data: b.Data}
+}
+
+// GetBinary returns BSON binary or error if subtype is greater than 255
+func (b *Binary) GetBinary() (primitive.Binary, error) {
+	if b.GetSubtype() > math.MaxUint8 {
+		return primitive.Binary{}, fmt.Errorf("invalid Binary subtype %d: must be in range from 0 to 255", b.GetSubtype())
+	}
+	return primitive.Binary{Subtype: byte(b.GetSubtype()), Data: b.GetData()}, nil
+}
+
+// Validate returns error if subtype is greater than 255
+func (b *Binary) Validate() error {
+	if b == nil {
+		return nil
+	}
+	_, err := b.GetBinary()
+	return err
+}
@@ -0,0 +1,506 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pmongo/primitive.proto
+
+package pmongo
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Timestamp is BSON timestamp used internally by MongoDB, e.g. in oplog.
+// Use google.protobuf.Timestamp for dates.
+type Timestamp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Seconds since Unix epoch
+	T uint32 `protobuf:"varint,1,opt,name=t,proto3" json:"t,omitempty"`
+	// Ordinal of operation within second
+	I             uint32 `protobuf:"varint,2,opt,name=i,proto3" json:"i,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timestamp) Reset() {
+	*x = Timestamp{}
+	mi := &file_pmongo_primitive_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timestamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timestamp) ProtoMessage() {}
+
+func (x *Timestamp) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_primitive_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timestamp.ProtoReflect.Descriptor instead.
+func (*Timestamp) Descriptor() ([]byte, []int) {
+	return file_pmongo_primitive_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Timestamp) GetT() uint32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *Timestamp) GetI() uint32 {
+	if x != nil {
+		return x.I
+	}
+	return 0
+}
+
+// Regex is BSON regular expression
+type Regex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pattern       string                 `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Options       string                 `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Regex) Reset() {
+	*x = Regex{}
+	mi := &file_pmongo_primitive_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Regex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Regex) ProtoMessage() {}
+
+func (x *Regex) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_primitive_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Regex.ProtoReflect.Descriptor instead.
+func (*Regex) Descriptor() ([]byte, []int) {
+	return file_pmongo_primitive_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Regex) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *Regex) GetOptions() string {
+	if x != nil {
+		return x.Options
+	}
+	return ""
+}
+
+// JavaScript is BSON JavaScript code
+type JavaScript struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JavaScript) Reset() {
+	*x = JavaScript{}
+	mi := &file_pmongo_primitive_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JavaScript) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JavaScript) ProtoMessage() {}
+
+func (x *JavaScript) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_primitive_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JavaScript.ProtoReflect.Descriptor instead.
+func (*JavaScript) Descriptor() ([]byte, []int) {
+	return file_pmongo_primitive_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JavaScript) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// DBPointer is deprecated BSON DBPointer
+type DBPointer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Namespace of referenced document
+	Db            string    `protobuf:"bytes,1,opt,name=db,proto3" json:"db,omitempty"`
+	Id            *ObjectId `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBPointer) Reset() {
+	*x = DBPointer{}
+	mi := &file_pmongo_primitive_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBPointer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBPointer) ProtoMessage() {}
+
+func (x *DBPointer) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_primitive_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBPointer.ProtoReflect.Descriptor instead.
+func (*DBPointer) Descriptor() ([]byte, []int) {
+	return file_pmongo_primitive_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DBPointer) GetDb() string {
+	if x != nil {
+		return x.Db
+	}
+	return ""
+}
+
+func (x *DBPointer) GetId() *ObjectId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+// DBRef is MongoDB DBRef convention document {$ref, $id, $db} referencing document by ObjectID
+type DBRef struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Collection of referenced document
+	Ref string    `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Id  *ObjectId `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Database of referenced document, optional
+	Db            string `protobuf:"bytes,3,opt,name=db,proto3" json:"db,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBRef) Reset() {
+	*x = DBRef{}
+	mi := &file_pmongo_primitive_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBRef) ProtoMessage() {}
+
+func (x *DBRef) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_primitive_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBRef.ProtoReflect.Descriptor instead.
+func (*DBRef) Descriptor() ([]byte, []int) {
+	return file_pmongo_primitive_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DBRef) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *DBRef) GetId() *ObjectId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *DBRef) GetDb() string {
+	if x != nil {
+		return x.Db
+	}
+	return ""
+}
+
+// MinKey is BSON MinKey comparing lower than all other BSON values
+type MinKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinKey) Reset() {
+	*x = MinKey{}
+	mi := &file_pmongo_primitive_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinKey) ProtoMessage() {}
+
+func (x *MinKey) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_primitive_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinKey.ProtoReflect.Descriptor instead.
+func (*MinKey) Descriptor() ([]byte, []int) {
+	return file_pmongo_primitive_proto_rawDescGZIP(), []int{5}
+}
+
+// MaxKey is BSON MaxKey comparing higher than all other BSON values
+type MaxKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaxKey) Reset() {
+	*x = MaxKey{}
+	mi := &file_pmongo_primitive_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaxKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaxKey) ProtoMessage() {}
+
+func (x *MaxKey) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_primitive_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaxKey.ProtoReflect.Descriptor instead.
+func (*MaxKey) Descriptor() ([]byte, []int) {
+	return file_pmongo_primitive_proto_rawDescGZIP(), []int{6}
+}
+
+// Binary is BSON binary having arbitrary subtype
+type Binary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtype       uint32                 `protobuf:"varint,1,opt,name=subtype,proto3" json:"subtype,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Binary) Reset() {
+	*x = Binary{}
+	mi := &file_pmongo_primitive_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Binary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Binary) ProtoMessage() {}
+
+func (x *Binary) ProtoReflect() protoreflect.Message {
+	mi := &file_pmongo_primitive_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Binary.ProtoReflect.Descriptor instead.
+func (*Binary) Descriptor() ([]byte, []int) {
+	return file_pmongo_primitive_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Binary) GetSubtype() uint32 {
+	if x != nil {
+		return x.Subtype
+	}
+	return 0
+}
+
+func (x *Binary) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_pmongo_primitive_proto protoreflect.FileDescriptor
+
+const file_pmongo_primitive_proto_rawDesc = "" +
+	"\n" +
+	"\x16pmongo/primitive.proto\x12\x06pmongo\x1a\x15pmongo/objectid.proto\"'\n" +
+	"\tTimestamp\x12\f\n" +
+	"\x01t\x18\x01 \x01(\rR\x01t\x12\f\n" +
+	"\x01i\x18\x02 \x01(\rR\x01i\";\n" +
+	"\x05Regex\x12\x18\n" +
+	"\apattern\x18\x01 \x01(\tR\apattern\x12\x18\n" +
+	"\aoptions\x18\x02 \x01(\tR\aoptions\" \n" +
+	"\n" +
+	"JavaScript\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"=\n" +
+	"\tDBPointer\x12\x0e\n" +
+	"\x02db\x18\x01 \x01(\tR\x02db\x12 \n" +
+	"\x02id\x18\x02 \x01(\v2\x10.pmongo.ObjectIdR\x02id\"K\n" +
+	"\x05DBRef\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\tR\x03ref\x12 \n" +
+	"\x02id\x18\x02 \x01(\v2\x10.pmongo.ObjectIdR\x02id\x12\x0e\n" +
+	"\x02db\x18\x03 \x01(\tR\x02db\"\b\n" +
+	"\x06MinKey\"\b\n" +
+	"\x06MaxKey\"6\n" +
+	"\x06Binary\x12\x18\n" +
+	"\asubtype\x18\x01 \x01(\rR\asubtype\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04dataB4Z2github.com/amsokol/mongo-go-driver-protobuf/pmongob\x06proto3"
+
+var (
+	file_pmongo_primitive_proto_rawDescOnce sync.Once
+	file_pmongo_primitive_proto_rawDescData []byte
+)
+
+func file_pmongo_primitive_proto_rawDescGZIP() []byte {
+	file_pmongo_primitive_proto_rawDescOnce.Do(func() {
+		file_pmongo_primitive_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pmongo_primitive_proto_rawDesc), len(file_pmongo_primitive_proto_rawDesc)))
+	})
+	return file_pmongo_primitive_proto_rawDescData
+}
+
+var file_pmongo_primitive_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_pmongo_primitive_proto_goTypes = []any{
+	(*Timestamp)(nil),  // 0: pmongo.Timestamp
+	(*Regex)(nil),      // 1: pmongo.Regex
+	(*JavaScript)(nil), // 2: pmongo.JavaScript
+	(*DBPointer)(nil),  // 3: pmongo.DBPointer
+	(*DBRef)(nil),      // 4: pmongo.DBRef
+	(*MinKey)(nil),     // 5: pmongo.MinKey
+	(*MaxKey)(nil),     // 6: pmongo.MaxKey
+	(*Binary)(nil),     // 7: pmongo.Binary
+	(*ObjectId)(nil),   // 8: pmongo.ObjectId
+}
+var file_pmongo_primitive_proto_depIdxs = []int32{
+	8, // 0: pmongo.DBPointer.id:type_name -> pmongo.ObjectId
+	8, // 1: pmongo.DBRef.id:type_name -> pmongo.ObjectId
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pmongo_primitive_proto_init() }
+func file_pmongo_primitive_proto_init() {
+	if File_pmongo_primitive_proto != nil {
+		return
+	}
+	file_pmongo_objectid_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pmongo_primitive_proto_rawDesc), len(file_pmongo_primitive_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pmongo_primitive_proto_goTypes,
+		DependencyIndexes: file_pmongo_primitive_proto_depIdxs,
+		MessageInfos:      file_pmongo_primitive_proto_msgTypes,
+	}.Build()
+	File_pmongo_primitive_proto = out.File
+	file_pmongo_primitive_proto_goTypes = nil
+	file_pmongo_primitive_proto_depIdxs = nil
+}
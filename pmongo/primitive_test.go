@@ -0,0 +1,113 @@
+package pmongo
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestExtendedJSON(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex("5c53a8ce7a0c1e0001d80f8e")
+
+	tests := []struct {
+		name string
+		in   proto.Message
+		out  proto.Message
+		json string
+	}{
+		{
+			name: "timestamp",
+			in:   NewTimestamp(primitive.Timestamp{T: 1549016430, I: 3}),
+			out:  &Timestamp{},
+			json: `{"$timestamp":{"t":1549016430,"i":3}}`,
+		},
+		{
+			name: "regex",
+			in:   NewRegex(primitive.Regex{Pattern: "^abc", Options: "i"}),
+			out:  &Regex{},
+			json: `{"$regularExpression":{"pattern":"^abc","options":"i"}}`,
+		},
+		{
+			name: "javascript",
+			in:   NewJavaScript("function() { return 1; }"),
+			out:  &JavaScript{},
+			json: `{"$code":"function() { return 1; }"}`,
+		},
+		{
+			name: "dbpointer",
+			in:   NewDBPointer(primitive.DBPointer{DB: "db.collection", Pointer: id}),
+			out:  &DBPointer{},
+			json: `{"$dbPointer":{"$ref":"db.collection","$id":{"$oid":"5c53a8ce7a0c1e0001d80f8e"}}}`,
+		},
+		{
+			name: "dbref",
+			in:   NewDBRef("collection", id, "db"),
+			out:  &DBRef{},
+			json: `{"$ref":"collection","$id":{"$oid":"5c53a8ce7a0c1e0001d80f8e"},"$db":"db"}`,
+		},
+		{
+			name: "dbref without db",
+			in:   NewDBRef("collection", id, ""),
+			out:  &DBRef{},
+			json: `{"$ref":"collection","$id":{"$oid":"5c53a8ce7a0c1e0001d80f8e"}}`,
+		},
+		{
+			name: "minkey",
+			in:   &MinKey{},
+			out:  &MinKey{},
+			json: `{"$minKey":1}`,
+		},
+		{
+			name: "maxkey",
+			in:   &MaxKey{},
+			out:  &MaxKey{},
+			json: `{"$maxKey":1}`,
+		},
+		{
+			name: "binary",
+			in:   NewBinary(primitive.Binary{Subtype: 0x80, Data: []byte("qwerty")}),
+			out:  &Binary{},
+			json: `{"$binary":{"base64":"cXdlcnR5","subtype":"80"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := new(jsonpb.Marshaler).MarshalToString(tt.in)
+			if err != nil {
+				t.Errorf("jsonpb.Marshaler error = %v", err)
+				return
+			}
+			if s != tt.json {
+				t.Errorf("failed: json=%s, expected=%s", s, tt.json)
+				return
+			}
+			if err = jsonpb.UnmarshalString(s, tt.out); err != nil {
+				t.Errorf("jsonpb.UnmarshalString error = %v", err)
+				return
+			}
+			if !proto.Equal(tt.in, tt.out) {
+				t.Errorf("failed: in=%v, out=%v", tt.in, tt.out)
+				return
+			}
+		})
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		for _, tt := range []struct {
+			out  proto.Message
+			json string
+		}{
+			{out: &Timestamp{}, json: `{"t":1,"i":2}`},
+			{out: &MinKey{}, json: `{"$maxKey":1}`},
+			{out: &DBRef{}, json: `{"$ref":"collection","$id":{"$oid":"bad"}}`},
+			{out: &Binary{}, json: `{"$binary":{"base64":"","subtype":"100"}}`},
+		} {
+			if err := jsonpb.UnmarshalString(tt.json, tt.out); err == nil {
+				t.Errorf("jsonpb.UnmarshalString error expected for %s", tt.json)
+				return
+			}
+		}
+	})
+}
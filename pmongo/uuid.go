@@ -0,0 +1,99 @@
+package pmongo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// uuidSize is size of UUID in bytes
+const uuidSize = 16
+
+// InvalidUUIDError is error of value which is not valid UUID
+type InvalidUUIDError struct {
+	// Value is invalid value, binary value is represented as hex string
+	Value string
+}
+
+// Error returns error message
+func (e *InvalidUUIDError) Error() string {
+	return fmt.Sprintf("invalid UUID %q: must be 16 bytes or 32 hex characters optionally separated by hyphens in 8-4-4-4-12 form", e.Value)
+}
+
+// NewUUID creates proto UUID having new random (version 4) UUID.
+// It panics if random source of crypto/rand fails.
+func NewUUID() *UUID {
+	b := make([]byte, uuidSize)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Errorf("failed to generate UUID: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return &UUID{Value: b}
+}
+
+// NewUUIDFromBytes creates proto UUID from 16 bytes in network byte order
+func NewUUIDFromBytes(b []byte) (*UUID, error) {
+	if len(b) != uuidSize {
+		return nil, &InvalidUUIDError{Value: hex.EncodeToString(b)}
+	}
+	return &UUID{Value: append([]byte(nil), b...)}, nil
+}
+
+// ParseUUID creates proto UUID from canonical text form, e.g. "123e4567-e89b-12d3-a456-426614174000".
+// Upper case hex characters and 32 hex characters without hyphens are accepted too.
+func ParseUUID(s string) (*UUID, error) {
+	h := s
+	if len(h) == 36 {
+		if h[8] != '-' || h[13] != '-' || h[18] != '-' || h[23] != '-' {
+			return nil, &InvalidUUIDError{Value: s}
+		}
+		h = strings.Replace(h, "-", "", -1)
+	}
+	if len(h) != 2*uuidSize {
+		return nil, &InvalidUUIDError{Value: s}
+	}
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return nil, &InvalidUUIDError{Value: s}
+	}
+	return &UUID{Value: b}, nil
+}
+
+// Text returns UUID in canonical text form or *InvalidUUIDError if value is not 16 bytes
+func (u *UUID) Text() (string, error) {
+	b := u.GetValue()
+	if len(b) != uuidSize {
+		return "", &InvalidUUIDError{Value: hex.EncodeToString(b)}
+	}
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}
+
+// Validate returns *InvalidUUIDError if value is not 16 bytes
+func (u *UUID) Validate() error {
+	if u == nil {
+		return nil
+	}
+	_, err := u.Text()
+	return err
+}
+
+// IsZero returns true if UUID is nil, empty or nil UUID having all zeros
+func (u *UUID) IsZero() bool {
+	for _, c := range u.GetValue() {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Version returns version of UUID, e.g. 4 for random UUID
+func (u *UUID) Version() int {
+	if len(u.GetValue()) != uuidSize {
+		return 0
+	}
+	return int(u.Value[6] >> 4)
+}
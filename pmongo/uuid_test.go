@@ -0,0 +1,88 @@
+package pmongo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+)
+
+func TestUUID(t *testing.T) {
+	t.Run("new", func(t *testing.T) {
+		u := NewUUID()
+		if err := u.Validate(); err != nil || u.IsZero() || u.Version() != 4 {
+			t.Errorf("failed: uuid=%v, error=%v", u, err)
+			return
+		}
+		if u.Value[8]&0xc0 != 0x80 {
+			t.Errorf("failed: variant of uuid=%v is not RFC 4122", u)
+			return
+		}
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		const canonical = "123e4567-e89b-12d3-a456-426614174000"
+		for _, s := range []string{canonical, "123E4567-E89B-12D3-A456-426614174000", "123e4567e89b12d3a456426614174000"} {
+			u, err := ParseUUID(s)
+			if err != nil {
+				t.Errorf("ParseUUID error = %v", err)
+				return
+			}
+			text, err := u.Text()
+			if err != nil || text != canonical {
+				t.Errorf("failed: text=%q, error=%v", text, err)
+				return
+			}
+		}
+		for _, s := range []string{"", "123e4567", "123e4567-e89b-12d3-a456_426614174000", "123e4567-e89b-12d3-a456-42661417400z"} {
+			_, err := ParseUUID(s)
+			var e *InvalidUUIDError
+			if !errors.As(err, &e) || e.Value != s {
+				t.Errorf("ParseUUID error = %v, *InvalidUUIDError expected for %q", err, s)
+				return
+			}
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		if err := (*UUID)(nil).Validate(); err != nil {
+			t.Errorf("UUID.Validate error = %v for nil UUID", err)
+			return
+		}
+		if err := (&UUID{Value: []byte{1, 2, 3}}).Validate(); err == nil {
+			t.Errorf("UUID.Validate error expected for 3 bytes")
+			return
+		}
+		if _, err := NewUUIDFromBytes(make([]byte, 15)); err == nil {
+			t.Errorf("NewUUIDFromBytes error expected for 15 bytes")
+			return
+		}
+	})
+
+	t.Run("jsonpb", func(t *testing.T) {
+		u := NewUUID()
+		s, err := new(jsonpb.Marshaler).MarshalToString(u)
+		if err != nil {
+			t.Errorf("jsonpb.Marshaler error = %v", err)
+			return
+		}
+		text, _ := u.Text()
+		if s != `"`+text+`"` {
+			t.Errorf("failed: json=%s, expected=%q", s, text)
+			return
+		}
+		var out UUID
+		if err = jsonpb.UnmarshalString(s, &out); err != nil {
+			t.Errorf("jsonpb.UnmarshalString error = %v", err)
+			return
+		}
+		if string(out.Value) != string(u.Value) {
+			t.Errorf("failed: in=%v, out=%v", u, &out)
+			return
+		}
+		if err = jsonpb.UnmarshalString(`"bad"`, &out); err == nil {
+			t.Errorf("jsonpb.UnmarshalString error expected for invalid UUID")
+			return
+		}
+	})
+}
@@ -0,0 +1,262 @@
+package codecs
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+)
+
+const (
+	// BSON keys of DBRef convention document
+	dbRefKey   = "$ref"
+	dbRefIDKey = "$id"
+	dbRefDBKey = "$db"
+)
+
+var (
+	// pmongo types of BSON primitives
+	dbRefType = reflect.TypeOf(pmongo.DBRef{})
+)
+
+// primitiveCodec is codec for pmongo message representing BSON primitive type.
+// Message is converted to and from matching primitive type encoded by codec registered for it.
+type primitiveCodec struct {
+	// primitiveType is type of primitive value, e.g. primitive.Timestamp
+	primitiveType reflect.Type
+	// toPrimitive converts message to primitive value
+	toPrimitive func(m proto.Message) (interface{}, error)
+	// fromPrimitive converts primitive value to message
+	fromPrimitive func(v interface{}) proto.Message
+}
+
+// primitiveCodecs returns codecs for pmongo messages representing BSON primitive types by message type
+func primitiveCodecs() map[reflect.Type]*primitiveCodec {
+	return map[reflect.Type]*primitiveCodec{
+		reflect.TypeOf(pmongo.Timestamp{}): {
+			primitiveType: reflect.TypeOf(primitive.Timestamp{}),
+			toPrimitive: func(m proto.Message) (interface{}, error) {
+				return m.(*pmongo.Timestamp).GetTimestamp(), nil
+			},
+			fromPrimitive: func(v interface{}) proto.Message {
+				return pmongo.NewTimestamp(v.(primitive.Timestamp))
+			},
+		},
+		reflect.TypeOf(pmongo.Regex{}): {
+			primitiveType: reflect.TypeOf(primitive.Regex{}),
+			toPrimitive: func(m proto.Message) (interface{}, error) {
+				return m.(*pmongo.Regex).GetRegex(), nil
+			},
+			fromPrimitive: func(v interface{}) proto.Message {
+				return pmongo.NewRegex(v.(primitive.Regex))
+			},
+		},
+		reflect.TypeOf(pmongo.JavaScript{}): {
+			primitiveType: reflect.TypeOf(primitive.JavaScript("")),
+			toPrimitive: func(m proto.Message) (interface{}, error) {
+				return m.(*pmongo.JavaScript).GetJavaScript(), nil
+			},
+			fromPrimitive: func(v interface{}) proto.Message {
+				return pmongo.NewJavaScript(v.(primitive.JavaScript))
+			},
+		},
+		reflect.TypeOf(pmongo.DBPointer{}): {
+			primitiveType: reflect.TypeOf(primitive.DBPointer{}),
+			toPrimitive: func(m proto.Message) (interface{}, error) {
+				return m.(*pmongo.DBPointer).GetDBPointer()
+			},
+			fromPrimitive: func(v interface{}) proto.Message {
+				return pmongo.NewDBPointer(v.(primitive.DBPointer))
+			},
+		},
+		reflect.TypeOf(pmongo.MinKey{}): {
+			primitiveType: reflect.TypeOf(primitive.MinKey{}),
+			toPrimitive: func(m proto.Message) (interface{}, error) {
+				return primitive.MinKey{}, nil
+			},
+			fromPrimitive: func(v interface{}) proto.Message {
+				return &pmongo.MinKey{}
+			},
+		},
+		reflect.TypeOf(pmongo.MaxKey{}): {
+			primitiveType: reflect.TypeOf(primitive.MaxKey{}),
+			toPrimitive: func(m proto.Message) (interface{}, error) {
+				return primitive.MaxKey{}, nil
+			},
+			fromPrimitive: func(v interface{}) proto.Message {
+				return &pmongo.MaxKey{}
+			},
+		},
+		reflect.TypeOf(pmongo.Binary{}): {
+			primitiveType: reflect.TypeOf(primitive.Binary{}),
+			toPrimitive: func(m proto.Message) (interface{}, error) {
+				return m.(*pmongo.Binary).GetBinary()
+			},
+			fromPrimitive: func(v interface{}) proto.Message {
+				return pmongo.NewBinary(v.(primitive.Binary))
+			},
+		},
+	}
+}
+
+// EncodeValue encodes pmongo message value to BSON value of primitive type
+func (e *primitiveCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	v, err := e.toPrimitive(messageOf(val))
+	if err != nil {
+		return err
+	}
+	enc, err := ectx.LookupEncoder(e.primitiveType)
+	if err != nil {
+		return err
+	}
+	return enc.EncodeValue(ectx, vw, reflect.ValueOf(v))
+}
+
+// DecodeValue decodes BSON value of primitive type to pmongo message value
+func (e *primitiveCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "primitiveCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+	if isNullValue(vr.Type()) {
+		setNullValue(val)
+		return readNullValue(vr)
+	}
+	dec, err := ectx.LookupDecoder(e.primitiveType)
+	if err != nil {
+		return err
+	}
+	v := reflect.New(e.primitiveType).Elem()
+	if err = dec.DecodeValue(ectx, vr, v); err != nil {
+		return err
+	}
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	setMessage(val, e.fromPrimitive(v.Interface()))
+	return nil
+}
+
+// dbRefCodec is codec for pmongo DBRef stored as DBRef convention document {$ref, $id, $db}
+type dbRefCodec struct {
+}
+
+// EncodeValue encodes pmongo DBRef value to BSON document
+func (e *dbRefCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	v := messageOf(val).(*pmongo.DBRef)
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	id, _ := v.GetId().GetObjectID()
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	evw, err := dw.WriteDocumentElement(dbRefKey)
+	if err != nil {
+		return err
+	}
+	if err = evw.WriteString(v.Ref); err != nil {
+		return err
+	}
+	if evw, err = dw.WriteDocumentElement(dbRefIDKey); err != nil {
+		return err
+	}
+	if err = evw.WriteObjectID(id); err != nil {
+		return err
+	}
+	if v.Db != "" {
+		if evw, err = dw.WriteDocumentElement(dbRefDBKey); err != nil {
+			return err
+		}
+		if err = evw.WriteString(v.Db); err != nil {
+			return err
+		}
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// DecodeValue decodes BSON DBRef convention document to pmongo DBRef value.
+// Extra keys of document are skipped.
+func (e *dbRefCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "dbRefCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+	if isNullValue(vr.Type()) {
+		setNullValue(val)
+		return readNullValue(vr)
+	}
+	dr, err := vr.ReadDocument()
+	if err != nil {
+		return err
+	}
+	var ref pmongo.DBRef
+	for {
+		key, evr, err := dr.ReadElement()
+		if err == bsonrw.ErrEOD {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch key {
+		case dbRefKey, dbRefDBKey:
+			if evr.Type() != bsontype.String {
+				return fmt.Errorf("DBRef key %q must be string, but got %v", key, evr.Type())
+			}
+			s, err := evr.ReadString()
+			if err != nil {
+				return err
+			}
+			if key == dbRefKey {
+				ref.Ref = s
+			} else {
+				ref.Db = s
+			}
+		case dbRefIDKey:
+			if evr.Type() != bsontype.ObjectID {
+				return fmt.Errorf("DBRef key %q must be ObjectID, but got %v", key, evr.Type())
+			}
+			id, err := evr.ReadObjectID()
+			if err != nil {
+				return err
+			}
+			ref.Id = pmongo.NewObjectId(id)
+		default:
+			if err = evr.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	if err = ref.Validate(); err != nil {
+		return err
+	}
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	setMessage(val, &ref)
+	return nil
+}
@@ -0,0 +1,107 @@
+package codecs
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestPrimitiveCodecs(t *testing.T) {
+	r := Register(bson.NewRegistryBuilder()).Build()
+	id := primitive.NewObjectID()
+
+	t.Run("decode existing document", func(t *testing.T) {
+		doc := bson.D{
+			{Key: "timestamp", Value: primitive.Timestamp{T: 1549016430, I: 3}},
+			{Key: "regex", Value: primitive.Regex{Pattern: "^abc", Options: "i"}},
+			{Key: "code", Value: primitive.JavaScript("function() { return 1; }")},
+			{Key: "pointer", Value: primitive.DBPointer{DB: "db.collection", Pointer: id}},
+			{Key: "ref", Value: bson.D{{Key: "$ref", Value: "collection"}, {Key: "$id", Value: id}, {Key: "$db", Value: "db"}}},
+			{Key: "min", Value: primitive.MinKey{}},
+			{Key: "max", Value: primitive.MaxKey{}},
+			{Key: "binary", Value: primitive.Binary{Subtype: 0x80, Data: []byte{1, 2, 3}}},
+		}
+		var out test.Primitives
+		if err := decodeDocument(r, doc, &out); err != nil {
+			t.Errorf("decodeDocument error = %v", err)
+			return
+		}
+		expect := &test.Primitives{
+			Timestamp: &pmongo.Timestamp{T: 1549016430, I: 3},
+			Regex:     &pmongo.Regex{Pattern: "^abc", Options: "i"},
+			Code:      &pmongo.JavaScript{Code: "function() { return 1; }"},
+			Pointer:   &pmongo.DBPointer{Db: "db.collection", Id: pmongo.NewObjectId(id)},
+			Ref:       pmongo.NewDBRef("collection", id, "db"),
+			Min:       &pmongo.MinKey{},
+			Max:       &pmongo.MaxKey{},
+			Binary:    &pmongo.Binary{Subtype: 0x80, Data: []byte{1, 2, 3}},
+		}
+		if !proto.Equal(expect, &out) {
+			t.Errorf("failed: expected=%v, out=%v", expect, &out)
+			return
+		}
+
+		// Encoded message is the same document
+		b, err := bson.MarshalWithRegistry(r, &out)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		var result bson.D
+		if err = bson.Unmarshal(b, &result); err != nil {
+			t.Errorf("bson.Unmarshal error = %v", err)
+			return
+		}
+		if len(result) != len(doc) {
+			t.Errorf("failed: expected=%v, result=%v", doc, result)
+			return
+		}
+		for i, e := range doc {
+			if result[i].Key != e.Key {
+				t.Errorf("failed: expected=%v, result=%v", doc, result)
+				return
+			}
+		}
+		if v := bson.Raw(b).Lookup("binary"); v.Type != bson.TypeBinary {
+			t.Errorf("failed: binary=%v", v)
+			return
+		}
+		if v := bson.Raw(b).Lookup("ref", "$id"); v.ObjectID() != id {
+			t.Errorf("failed: ref=%v", bson.Raw(b).Lookup("ref"))
+			return
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		runFailCases(t, r, []failCase{
+			{
+				name:   "binary subtype out of range",
+				in:     &test.Primitives{Binary: &pmongo.Binary{Subtype: 256}},
+				reason: "invalid Binary subtype 256",
+			},
+			{
+				name:   "DBRef without id",
+				in:     &test.Primitives{Ref: &pmongo.DBRef{Ref: "collection"}},
+				target: new(*pmongo.ErrInvalidObjectId),
+				reason: `invalid ObjectId ""`,
+			},
+			{
+				name:   "DBPointer with invalid id",
+				in:     &test.Primitives{Pointer: &pmongo.DBPointer{Db: "db.collection", Id: &pmongo.ObjectId{Value: "bad"}}},
+				target: new(*pmongo.ErrInvalidObjectId),
+				reason: `invalid ObjectId "bad"`,
+			},
+			{
+				name:   "DBRef with string id",
+				doc:    bson.D{{Key: "ref", Value: bson.D{{Key: "$ref", Value: "collection"}, {Key: "$id", Value: "qwerty"}}}},
+				out:    &test.Primitives{},
+				reason: `DBRef key "$id" must be ObjectID, but got string`,
+			},
+		})
+	})
+}
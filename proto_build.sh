@@ -1,5 +1,12 @@
 #bash
  
-protoc --proto_path=proto/pmongo --go_out=../../../ objectid.proto
+protoc --proto_path=proto --go_out=paths=source_relative:. pmongo/objectid.proto
+protoc --proto_path=proto --go_out=paths=source_relative:. pmongo/decimal128.proto
+protoc --proto_path=proto --go_out=paths=source_relative:. pmongo/uuid.proto
+protoc --proto_path=proto --go_out=paths=source_relative:. pmongo/primitive.proto
+protoc --proto_path=proto --go_out=paths=source_relative:. pmongo/geojson.proto
 
-protoc --proto_path=test --proto_path=proto --proto_path=proto/third_party --go_out=test codecs_test.proto
+protoc --proto_path=test --proto_path=proto --proto_path=proto/third_party --go_out=paths=source_relative:test codecs_test.proto
+
+# legacy.proto is generated by protoc-gen-go of github.com/golang/protobuf v1.2 to test legacy API messages
+protoc --proto_path=test/legacy --proto_path=proto --proto_path=proto/third_party --go_out=paths=source_relative,Mgoogle/protobuf/timestamp.proto=github.com/golang/protobuf/ptypes/timestamp,Mgoogle/protobuf/wrappers.proto=github.com/golang/protobuf/ptypes/wrappers:test/legacy legacy.proto
@@ -0,0 +1,298 @@
+package codecs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	// Protobuf Struct types
+	structType    = reflect.TypeOf(structpb.Struct{})
+	valueType     = reflect.TypeOf(structpb.Value{})
+	listValueType = reflect.TypeOf(structpb.ListValue{})
+
+	// Codecs
+	structCodecRef    = &structCodec{}
+	valueCodecRef     = &valueCodec{}
+	listValueCodecRef = &listValueCodec{}
+)
+
+// structCodec is codec for Protobuf Struct.
+// Struct is stored as BSON embedded document.
+type structCodec struct {
+}
+
+// EncodeValue encodes Protobuf Struct value to BSON value
+func (e *structCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	return encodeStruct(vw, messageOf(val).(*structpb.Struct))
+}
+
+// DecodeValue decodes BSON value to Struct value
+func (e *structCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	s, err := decodeStruct(vr)
+	if err != nil {
+		return err
+	}
+	setMessage(val, s)
+	return nil
+}
+
+// valueCodec is codec for Protobuf Value.
+// Value is stored as matching BSON value (null, double, string, bool, embedded document or array).
+type valueCodec struct {
+}
+
+// EncodeValue encodes Protobuf Value value to BSON value
+func (e *valueCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	return encodeValue(vw, messageOf(val).(*structpb.Value))
+}
+
+// DecodeValue decodes BSON value to Value value
+func (e *valueCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	v, err := decodeValue(vr)
+	if err != nil {
+		return err
+	}
+	setMessage(val, v)
+	return nil
+}
+
+// listValueCodec is codec for Protobuf ListValue.
+// ListValue is stored as BSON array.
+type listValueCodec struct {
+}
+
+// EncodeValue encodes Protobuf ListValue value to BSON value
+func (e *listValueCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	return encodeListValue(vw, messageOf(val).(*structpb.ListValue))
+}
+
+// DecodeValue decodes BSON value to ListValue value
+func (e *listValueCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	l, err := decodeListValue(vr)
+	if err != nil {
+		return err
+	}
+	setMessage(val, l)
+	return nil
+}
+
+// encodeStruct writes Struct as BSON document with keys in sorted order
+func encodeStruct(vw bsonrw.ValueWriter, s *structpb.Struct) error {
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(s.GetFields()))
+	for k := range s.GetFields() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		evw, err := dw.WriteDocumentElement(k)
+		if err != nil {
+			return err
+		}
+		if err = encodeValue(evw, s.Fields[k]); err != nil {
+			return err
+		}
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// encodeListValue writes ListValue as BSON array
+func encodeListValue(vw bsonrw.ValueWriter, l *structpb.ListValue) error {
+	aw, err := vw.WriteArray()
+	if err != nil {
+		return err
+	}
+	for _, v := range l.GetValues() {
+		evw, err := aw.WriteArrayElement()
+		if err != nil {
+			return err
+		}
+		if err = encodeValue(evw, v); err != nil {
+			return err
+		}
+	}
+	return aw.WriteArrayEnd()
+}
+
+// encodeValue writes Value as matching BSON value
+func encodeValue(vw bsonrw.ValueWriter, v *structpb.Value) error {
+	switch k := v.GetKind().(type) {
+	case nil, *structpb.Value_NullValue:
+		return vw.WriteNull()
+	case *structpb.Value_NumberValue:
+		return vw.WriteDouble(k.NumberValue)
+	case *structpb.Value_StringValue:
+		return vw.WriteString(k.StringValue)
+	case *structpb.Value_BoolValue:
+		return vw.WriteBoolean(k.BoolValue)
+	case *structpb.Value_StructValue:
+		return encodeStruct(vw, k.StructValue)
+	case *structpb.Value_ListValue:
+		return encodeListValue(vw, k.ListValue)
+	default:
+		return fmt.Errorf("unknown Value kind %T", k)
+	}
+}
+
+// decodeStruct reads BSON document to Struct
+func decodeStruct(vr bsonrw.ValueReader) (*structpb.Struct, error) {
+	switch vr.Type() {
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	default:
+		return nil, fmt.Errorf("cannot decode %v into a Struct", vr.Type())
+	}
+	dr, err := vr.ReadDocument()
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for {
+		key, evr, err := dr.ReadElement()
+		if err == bsonrw.ErrEOD {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		v, err := decodeValue(evr)
+		if err != nil {
+			return nil, err
+		}
+		s.Fields[key] = v
+	}
+	return s, nil
+}
+
+// decodeListValue reads BSON array to ListValue
+func decodeListValue(vr bsonrw.ValueReader) (*structpb.ListValue, error) {
+	if vr.Type() != bsontype.Array {
+		return nil, fmt.Errorf("cannot decode %v into a ListValue", vr.Type())
+	}
+	ar, err := vr.ReadArray()
+	if err != nil {
+		return nil, err
+	}
+	l := &structpb.ListValue{Values: []*structpb.Value{}}
+	for {
+		evr, err := ar.ReadValue()
+		if err == bsonrw.ErrEOA {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		v, err := decodeValue(evr)
+		if err != nil {
+			return nil, err
+		}
+		l.Values = append(l.Values, v)
+	}
+	return l, nil
+}
+
+// decodeValue reads BSON value to Value.
+// BSON types having no JSON equivalent are converted the same way as Extended JSON
+// relaxed mode does: integers to number, date to RFC 3339 string, ObjectID to hex string,
+// Decimal128 to decimal string and binary to base64 string.
+func decodeValue(vr bsonrw.ValueReader) (*structpb.Value, error) {
+	switch vr.Type() {
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_NullValue{}}, nil
+	case bsontype.Undefined:
+		if err := vr.ReadUndefined(); err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_NullValue{}}, nil
+	case bsontype.Double:
+		f, err := vr.ReadDouble()
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: f}}, nil
+	case bsontype.Int32:
+		i, err := vr.ReadInt32()
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(i)}}, nil
+	case bsontype.Int64:
+		i, err := vr.ReadInt64()
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(i)}}, nil
+	case bsontype.String:
+		s, err := vr.ReadString()
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}, nil
+	case bsontype.Symbol:
+		s, err := vr.ReadSymbol()
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}, nil
+	case bsontype.Boolean:
+		b, err := vr.ReadBoolean()
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: b}}, nil
+	case bsontype.EmbeddedDocument:
+		s, err := decodeStruct(vr)
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: s}}, nil
+	case bsontype.Array:
+		l, err := decodeListValue(vr)
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: l}}, nil
+	case bsontype.DateTime:
+		dt, err := vr.ReadDateTime()
+		if err != nil {
+			return nil, err
+		}
+		s := time.Unix(dt/1000, dt%1000*1000000).UTC().Format(time.RFC3339Nano)
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}, nil
+	case bsontype.ObjectID:
+		id, err := vr.ReadObjectID()
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: id.Hex()}}, nil
+	case bsontype.Decimal128:
+		d, err := vr.ReadDecimal128()
+		if err != nil {
+			return nil, err
+		}
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: d.String()}}, nil
+	case bsontype.Binary:
+		b, _, err := vr.ReadBinary()
+		if err != nil {
+			return nil, err
+		}
+		s := base64.StdEncoding.EncodeToString(b)
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}, nil
+	default:
+		return nil, fmt.Errorf("cannot decode %v into a Value", vr.Type())
+	}
+}
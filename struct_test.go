@@ -0,0 +1,96 @@
+package codecs
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestStructCodecs(t *testing.T) {
+	rb := bson.NewRegistryBuilder()
+	r := Register(rb).Build()
+
+	t.Run("struct is stored as document", func(t *testing.T) {
+		in := &test.Data{
+			Struct: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"name": {Kind: &structpb.Value_StringValue{StringValue: "qwerty"}},
+			}},
+			List: &structpb.ListValue{Values: []*structpb.Value{
+				{Kind: &structpb.Value_NumberValue{NumberValue: 1}},
+			}},
+		}
+
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+
+		name, err := bson.Raw(b).LookupErr("struct", "name")
+		if err != nil {
+			t.Errorf("bson.Raw.LookupErr error = %v", err)
+			return
+		}
+		if s, ok := name.StringValueOK(); !ok || s != "qwerty" {
+			t.Errorf("failed: struct.name=%v", name)
+			return
+		}
+
+		list, err := bson.Raw(b).LookupErr("list")
+		if err != nil {
+			t.Errorf("bson.Raw.LookupErr error = %v", err)
+			return
+		}
+		if _, ok := list.ArrayOK(); !ok {
+			t.Errorf("failed: list=%v", list)
+			return
+		}
+	})
+
+	t.Run("arbitrary document to struct", func(t *testing.T) {
+		dt := time.Date(2019, 2, 1, 10, 20, 30, 0, time.UTC)
+		id := primitive.NewObjectID()
+
+		b, err := bson.Marshal(bson.D{
+			{Key: "struct", Value: bson.D{
+				{Key: "int32", Value: int32(1)},
+				{Key: "int64", Value: int64(2)},
+				{Key: "date", Value: primitive.DateTime(dt.UnixNano() / int64(time.Millisecond))},
+				{Key: "id", Value: id},
+				{Key: "array", Value: bson.A{"a", nil}},
+			}},
+		})
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+
+		expected := &structpb.Struct{Fields: map[string]*structpb.Value{
+			"int32": {Kind: &structpb.Value_NumberValue{NumberValue: 1}},
+			"int64": {Kind: &structpb.Value_NumberValue{NumberValue: 2}},
+			"date":  {Kind: &structpb.Value_StringValue{StringValue: "2019-02-01T10:20:30Z"}},
+			"id":    {Kind: &structpb.Value_StringValue{StringValue: id.Hex()}},
+			"array": {Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: []*structpb.Value{
+				{Kind: &structpb.Value_StringValue{StringValue: "a"}},
+				{Kind: &structpb.Value_NullValue{}},
+			}}}},
+		}}
+
+		if !proto.Equal(expected, out.Struct) {
+			t.Errorf("failed: expected=%v, out=%v", expected, out.Struct)
+			return
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package codecs
+
+import (
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+)
+
+// ProtoStructTagParser is struct tag parser keeping lowercased Go field names as keys
+// the same way as bsoncodec.DefaultStructTagParser does. It is not used by Register,
+// which derives keys from proto field names; use KeyLowercase to read documents keyed by Go names.
+// It skips protobuf runtime internals (XXX_NoUnkeyedLiteral, XXX_unrecognized, XXX_sizecache)
+// of structs generated by legacy protoc-gen-go, so they are not written to documents even if
+// messages are encoded by default struct codec (e.g. TypeMessage is not registered or message
+// struct is embedded by value into Go struct). Other fields are parsed by bsoncodec.DefaultStructTagParser.
+var ProtoStructTagParser bsoncodec.StructTagParserFunc = func(sf reflect.StructField) (bsoncodec.StructTags, error) {
+	if strings.HasPrefix(sf.Name, "XXX_") {
+		return bsoncodec.StructTags{Skip: true}, nil
+	}
+	return bsoncodec.DefaultStructTagParser(sf)
+}
+
+// protoStructTagParser is struct tag parser deriving BSON keys from protobuf struct tags
+type protoStructTagParser struct {
+	keyNaming KeyNaming
+}
+
+// NewProtoStructTagParser creates struct tag parser of default struct codec registered by RegisterWithOptions.
+// It derives BSON keys of generated struct fields from protobuf struct tag: proto field name (name=)
+// with KeyProtoName, JSON name (json=, or name= if it is the same) with KeyJSONName or lowercased
+// Go field name with KeyLowercase, so documents look the same as written by message codec. Field "_id" keeps its name as primary key with any naming.
+// Key of "bson" struct tag overrides the key,
+// fields without protobuf struct tag are parsed by bsoncodec.DefaultStructTagParser.
+// Protobuf runtime internals are skipped.
+func NewProtoStructTagParser(k KeyNaming) bsoncodec.StructTagParser {
+	return &protoStructTagParser{keyNaming: k}
+}
+
+// ParseStructTags parses struct field tags
+func (p *protoStructTagParser) ParseStructTags(sf reflect.StructField) (bsoncodec.StructTags, error) {
+	st, err := ProtoStructTagParser(sf)
+	if err != nil || st.Skip {
+		return st, err
+	}
+	if tag, ok := sf.Tag.Lookup("bson"); ok && strings.Split(tag, ",")[0] != "" {
+		// Key of "bson" struct tag having only options (e.g. ",omitempty") is derived
+		// from protobuf struct tag the same way as message codec does
+		return st, nil
+	}
+	var name, jsonName string
+	for _, opt := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+		switch {
+		case strings.HasPrefix(opt, "name="):
+			name = strings.TrimPrefix(opt, "name=")
+		case strings.HasPrefix(opt, "json="):
+			jsonName = strings.TrimPrefix(opt, "json=")
+		}
+	}
+	if name == "" {
+		return st, nil
+	}
+	st.Name = name
+	if name == insertedIDKey {
+		// Primary key is kept verbatim whatever key naming is
+		return st, nil
+	}
+	switch p.keyNaming {
+	case KeyJSONName:
+		if jsonName != "" {
+			st.Name = jsonName
+		}
+	case KeyLowercase:
+		st.Name = strings.ToLower(sf.Name)
+	}
+	return st, nil
+}
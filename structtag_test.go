@@ -0,0 +1,138 @@
+package codecs
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+// optionsTagged is struct having "bson" struct tag with options only
+type optionsTagged struct {
+	FullName string `protobuf:"bytes,1,opt,name=full_name,json=fullName,proto3" bson:",omitempty"`
+}
+
+func TestProtoStructTagParser(t *testing.T) {
+	in := &test.Record{
+		XId:  pmongo.NewObjectIdNow(),
+		Name: "qwerty",
+	}
+
+	tests := []struct {
+		name   string
+		parser bsoncodec.StructTagParser
+		keys   []string
+	}{
+		{name: "Go names", parser: ProtoStructTagParser, keys: []string{"xid", "name"}},
+		{name: "proto names", parser: NewProtoStructTagParser(KeyProtoName), keys: []string{"_id", "name"}},
+		{name: "JSON names", parser: NewProtoStructTagParser(KeyJSONName), keys: []string{"_id", "name"}},
+		{name: "lowercased Go names", parser: NewProtoStructTagParser(KeyLowercase), keys: []string{"_id", "name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := bsoncodec.NewStructCodec(tt.parser)
+			if err != nil {
+				t.Errorf("bsoncodec.NewStructCodec error = %v", err)
+				return
+			}
+			rb := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTypes(TypeAll&^TypeMessage)))
+			r := rb.RegisterDefaultEncoder(reflect.Struct, sc).RegisterDefaultDecoder(reflect.Struct, sc).Build()
+
+			b, err := bson.MarshalWithRegistry(r, in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			var doc bson.M
+			if err = bson.Unmarshal(b, &doc); err != nil {
+				t.Errorf("bson.Unmarshal error = %v", err)
+				return
+			}
+			for _, key := range tt.keys {
+				if _, ok := doc[key]; !ok {
+					t.Errorf("failed: key %q is not found in %v", key, doc)
+					return
+				}
+			}
+
+			var out test.Record
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(in, &out) {
+				t.Errorf("failed: in=%v, out=%v", in, &out)
+				return
+			}
+		})
+	}
+
+	t.Run("default struct codec", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTypes(TypeAll&^TypeMessage))).Build()
+
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if _, err = bson.Raw(b).LookupErr("_id"); err != nil {
+			t.Errorf("failed: key _id is not found in %v", bson.Raw(b))
+			return
+		}
+	})
+
+	t.Run("key naming option", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(),
+			NewOptions(WithTypes(TypeAll&^TypeMessage), WithKeyNaming(KeyJSONName))).Build()
+
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if _, err = bson.Raw(b).LookupErr("_id"); err != nil {
+			t.Errorf("failed: key _id is not found in %v", bson.Raw(b))
+			return
+		}
+	})
+	t.Run("own struct codec", func(t *testing.T) {
+		sc, err := bsoncodec.NewStructCodec(bsoncodec.DefaultStructTagParser)
+		if err != nil {
+			t.Errorf("bsoncodec.NewStructCodec error = %v", err)
+			return
+		}
+		rb := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTypes(TypeAll&^TypeMessage)))
+		r := rb.RegisterDefaultEncoder(reflect.Struct, sc).
+			RegisterDefaultDecoder(reflect.Struct, sc).
+			Build()
+
+		b, err := bson.MarshalWithRegistry(r, &optionsTagged{FullName: "qwerty"})
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if _, err = bson.Raw(b).LookupErr("fullname"); err != nil {
+			t.Errorf("failed: key fullname is not found in %v", bson.Raw(b))
+			return
+		}
+	})
+
+	t.Run("bson tag options only", func(t *testing.T) {
+		for k, key := range map[KeyNaming]string{KeyProtoName: "full_name", KeyJSONName: "fullName"} {
+			st, err := NewProtoStructTagParser(k).ParseStructTags(reflect.TypeOf(optionsTagged{}).Field(0))
+			if err != nil {
+				t.Errorf("ParseStructTags error = %v", err)
+				return
+			}
+			if st.Name != key || !st.OmitEmpty {
+				t.Errorf("failed: tags=%+v, expected key %q", st, key)
+				return
+			}
+		}
+	})
+}
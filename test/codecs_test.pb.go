@@ -1,173 +1,848 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
 // source: codecs_test.proto
 
 package test
 
 import (
-	fmt "fmt"
 	pmongo "github.com/amsokol/mongo-go-driver-protobuf/pmongo"
-	proto "github.com/golang/protobuf/proto"
-	timestamp "github.com/golang/protobuf/ptypes/timestamp"
-	wrappers "github.com/golang/protobuf/ptypes/wrappers"
-	math "math"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_RED               Color = 1
+	Color_GREEN             Color = 2
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "COLOR_UNSPECIFIED",
+		1: "RED",
+		2: "GREEN",
+	}
+	Color_value = map[string]int32{
+		"COLOR_UNSPECIFIED": 0,
+		"RED":               1,
+		"GREEN":             2,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_codecs_test_proto_enumTypes[0].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_codecs_test_proto_enumTypes[0]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_codecs_test_proto_rawDescGZIP(), []int{0}
+}
 
 type Data struct {
-	BoolValue            *wrappers.BoolValue   `protobuf:"bytes,1,opt,name=boolValue,proto3" json:"boolValue,omitempty"`
-	BytesValue           *wrappers.BytesValue  `protobuf:"bytes,2,opt,name=bytesValue,proto3" json:"bytesValue,omitempty"`
-	DoubleValue          *wrappers.DoubleValue `protobuf:"bytes,3,opt,name=doubleValue,proto3" json:"doubleValue,omitempty"`
-	FloatValue           *wrappers.FloatValue  `protobuf:"bytes,4,opt,name=floatValue,proto3" json:"floatValue,omitempty"`
-	Int32Value           *wrappers.Int32Value  `protobuf:"bytes,5,opt,name=int32Value,proto3" json:"int32Value,omitempty"`
-	Int64Value           *wrappers.Int64Value  `protobuf:"bytes,6,opt,name=int64Value,proto3" json:"int64Value,omitempty"`
-	StringValue          *wrappers.StringValue `protobuf:"bytes,7,opt,name=stringValue,proto3" json:"stringValue,omitempty"`
-	Uint32Value          *wrappers.UInt32Value `protobuf:"bytes,8,opt,name=uint32Value,proto3" json:"uint32Value,omitempty"`
-	Uint64Value          *wrappers.UInt64Value `protobuf:"bytes,9,opt,name=uint64Value,proto3" json:"uint64Value,omitempty"`
-	Timestamp            *timestamp.Timestamp  `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Id                   *pmongo.ObjectId      `protobuf:"bytes,11,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
-}
-
-func (m *Data) Reset()         { *m = Data{} }
-func (m *Data) String() string { return proto.CompactTextString(m) }
-func (*Data) ProtoMessage()    {}
+	state       protoimpl.MessageState  `protogen:"open.v1"`
+	BoolValue   *wrapperspb.BoolValue   `protobuf:"bytes,1,opt,name=boolValue,proto3" json:"boolValue,omitempty"`
+	BytesValue  *wrapperspb.BytesValue  `protobuf:"bytes,2,opt,name=bytesValue,proto3" json:"bytesValue,omitempty"`
+	DoubleValue *wrapperspb.DoubleValue `protobuf:"bytes,3,opt,name=doubleValue,proto3" json:"doubleValue,omitempty"`
+	FloatValue  *wrapperspb.FloatValue  `protobuf:"bytes,4,opt,name=floatValue,proto3" json:"floatValue,omitempty"`
+	Int32Value  *wrapperspb.Int32Value  `protobuf:"bytes,5,opt,name=int32Value,proto3" json:"int32Value,omitempty"`
+	Int64Value  *wrapperspb.Int64Value  `protobuf:"bytes,6,opt,name=int64Value,proto3" json:"int64Value,omitempty"`
+	StringValue *wrapperspb.StringValue `protobuf:"bytes,7,opt,name=stringValue,proto3" json:"stringValue,omitempty"`
+	Uint32Value *wrapperspb.UInt32Value `protobuf:"bytes,8,opt,name=uint32Value,proto3" json:"uint32Value,omitempty"`
+	Uint64Value *wrapperspb.UInt64Value `protobuf:"bytes,9,opt,name=uint64Value,proto3" json:"uint64Value,omitempty"`
+	Timestamp   *timestamppb.Timestamp  `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Id          *pmongo.ObjectId        `protobuf:"bytes,11,opt,name=id,proto3" json:"id,omitempty"`
+	Duration    *durationpb.Duration    `protobuf:"bytes,12,opt,name=duration,proto3" json:"duration,omitempty"`
+	Struct      *structpb.Struct        `protobuf:"bytes,13,opt,name=struct,proto3" json:"struct,omitempty"`
+	Value       *structpb.Value         `protobuf:"bytes,14,opt,name=value,proto3" json:"value,omitempty"`
+	List        *structpb.ListValue     `protobuf:"bytes,15,opt,name=list,proto3" json:"list,omitempty"`
+	Any         *anypb.Any              `protobuf:"bytes,16,opt,name=any,proto3" json:"any,omitempty"`
+	SnakeCase   string                  `protobuf:"bytes,17,opt,name=snake_case,json=snakeCase,proto3" json:"snake_case,omitempty"`
+	Children    []*Data                 `protobuf:"bytes,18,rep,name=children,proto3" json:"children,omitempty"`
+	Color       Color                   `protobuf:"varint,19,opt,name=color,proto3,enum=test.Color" json:"color,omitempty"`
+	Colors      []Color                 `protobuf:"varint,20,rep,packed,name=colors,proto3,enum=test.Color" json:"colors,omitempty"`
+	Counter     uint64                  `protobuf:"varint,21,opt,name=counter,proto3" json:"counter,omitempty"`
+	BinaryId    *pmongo.BinaryObjectId  `protobuf:"bytes,22,opt,name=binary_id,json=binaryId,proto3" json:"binary_id,omitempty"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Data_Name
+	//	*Data_Number
+	//	*Data_Time
+	//	*Data_Child
+	Kind          isData_Kind                `protobuf_oneof:"kind"`
+	Labels        map[int32]string           `protobuf:"bytes,27,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Flags         map[bool]*Data             `protobuf:"bytes,28,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Amount        *pmongo.Decimal128         `protobuf:"bytes,29,opt,name=amount,proto3" json:"amount,omitempty"`
+	Uuid          *pmongo.UUID               `protobuf:"bytes,30,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Values        []*structpb.Value          `protobuf:"bytes,31,rep,name=values,proto3" json:"values,omitempty"`
+	Attributes    map[string]*structpb.Value `protobuf:"bytes,32,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data) Reset() {
+	*x = Data{}
+	mi := &file_codecs_test_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data) ProtoMessage() {}
+
+func (x *Data) ProtoReflect() protoreflect.Message {
+	mi := &file_codecs_test_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data.ProtoReflect.Descriptor instead.
 func (*Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b2b3e361c7bc6717, []int{0}
+	return file_codecs_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Data) GetBoolValue() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.BoolValue
+	}
+	return nil
+}
+
+func (x *Data) GetBytesValue() *wrapperspb.BytesValue {
+	if x != nil {
+		return x.BytesValue
+	}
+	return nil
+}
+
+func (x *Data) GetDoubleValue() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.DoubleValue
+	}
+	return nil
+}
+
+func (x *Data) GetFloatValue() *wrapperspb.FloatValue {
+	if x != nil {
+		return x.FloatValue
+	}
+	return nil
+}
+
+func (x *Data) GetInt32Value() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.Int32Value
+	}
+	return nil
+}
+
+func (x *Data) GetInt64Value() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Int64Value
+	}
+	return nil
+}
+
+func (x *Data) GetStringValue() *wrapperspb.StringValue {
+	if x != nil {
+		return x.StringValue
+	}
+	return nil
+}
+
+func (x *Data) GetUint32Value() *wrapperspb.UInt32Value {
+	if x != nil {
+		return x.Uint32Value
+	}
+	return nil
+}
+
+func (x *Data) GetUint64Value() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.Uint64Value
+	}
+	return nil
+}
+
+func (x *Data) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Data) GetId() *pmongo.ObjectId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Data) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Data) GetStruct() *structpb.Struct {
+	if x != nil {
+		return x.Struct
+	}
+	return nil
+}
+
+func (x *Data) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Data) GetList() *structpb.ListValue {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *Data) GetAny() *anypb.Any {
+	if x != nil {
+		return x.Any
+	}
+	return nil
+}
+
+func (x *Data) GetSnakeCase() string {
+	if x != nil {
+		return x.SnakeCase
+	}
+	return ""
+}
+
+func (x *Data) GetChildren() []*Data {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Data) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *Data) GetColors() []Color {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
 }
 
-func (m *Data) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Data.Unmarshal(m, b)
+func (x *Data) GetCounter() uint64 {
+	if x != nil {
+		return x.Counter
+	}
+	return 0
 }
-func (m *Data) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Data.Marshal(b, m, deterministic)
+
+func (x *Data) GetBinaryId() *pmongo.BinaryObjectId {
+	if x != nil {
+		return x.BinaryId
+	}
+	return nil
 }
-func (m *Data) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Data.Merge(m, src)
+
+func (x *Data) GetKind() isData_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
 }
-func (m *Data) XXX_Size() int {
-	return xxx_messageInfo_Data.Size(m)
+
+func (x *Data) GetName() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Data_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
 }
-func (m *Data) XXX_DiscardUnknown() {
-	xxx_messageInfo_Data.DiscardUnknown(m)
+
+func (x *Data) GetNumber() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Data_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
 }
 
-var xxx_messageInfo_Data proto.InternalMessageInfo
+func (x *Data) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Kind.(*Data_Time); ok {
+			return x.Time
+		}
+	}
+	return nil
+}
 
-func (m *Data) GetBoolValue() *wrappers.BoolValue {
-	if m != nil {
-		return m.BoolValue
+func (x *Data) GetChild() *Data {
+	if x != nil {
+		if x, ok := x.Kind.(*Data_Child); ok {
+			return x.Child
+		}
 	}
 	return nil
 }
 
-func (m *Data) GetBytesValue() *wrappers.BytesValue {
-	if m != nil {
-		return m.BytesValue
+func (x *Data) GetLabels() map[int32]string {
+	if x != nil {
+		return x.Labels
 	}
 	return nil
 }
 
-func (m *Data) GetDoubleValue() *wrappers.DoubleValue {
-	if m != nil {
-		return m.DoubleValue
+func (x *Data) GetFlags() map[bool]*Data {
+	if x != nil {
+		return x.Flags
 	}
 	return nil
 }
 
-func (m *Data) GetFloatValue() *wrappers.FloatValue {
-	if m != nil {
-		return m.FloatValue
+func (x *Data) GetAmount() *pmongo.Decimal128 {
+	if x != nil {
+		return x.Amount
 	}
 	return nil
 }
 
-func (m *Data) GetInt32Value() *wrappers.Int32Value {
-	if m != nil {
-		return m.Int32Value
+func (x *Data) GetUuid() *pmongo.UUID {
+	if x != nil {
+		return x.Uuid
 	}
 	return nil
 }
 
-func (m *Data) GetInt64Value() *wrappers.Int64Value {
-	if m != nil {
-		return m.Int64Value
+func (x *Data) GetValues() []*structpb.Value {
+	if x != nil {
+		return x.Values
 	}
 	return nil
 }
 
-func (m *Data) GetStringValue() *wrappers.StringValue {
-	if m != nil {
-		return m.StringValue
+func (x *Data) GetAttributes() map[string]*structpb.Value {
+	if x != nil {
+		return x.Attributes
 	}
 	return nil
 }
 
-func (m *Data) GetUint32Value() *wrappers.UInt32Value {
-	if m != nil {
-		return m.Uint32Value
+type isData_Kind interface {
+	isData_Kind()
+}
+
+type Data_Name struct {
+	Name string `protobuf:"bytes,23,opt,name=name,proto3,oneof"`
+}
+
+type Data_Number struct {
+	Number int64 `protobuf:"varint,24,opt,name=number,proto3,oneof"`
+}
+
+type Data_Time struct {
+	Time *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=time,proto3,oneof"`
+}
+
+type Data_Child struct {
+	Child *Data `protobuf:"bytes,26,opt,name=child,proto3,oneof"`
+}
+
+func (*Data_Name) isData_Kind() {}
+
+func (*Data_Number) isData_Kind() {}
+
+func (*Data_Time) isData_Kind() {}
+
+func (*Data_Child) isData_Kind() {}
+
+type Record struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	XId           *pmongo.ObjectId       `protobuf:"bytes,1,opt,name=_id,json=Id,proto3" json:"_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_codecs_test_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_codecs_test_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_codecs_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Record) GetXId() *pmongo.ObjectId {
+	if x != nil {
+		return x.XId
 	}
 	return nil
 }
 
-func (m *Data) GetUint64Value() *wrappers.UInt64Value {
-	if m != nil {
-		return m.Uint64Value
+func (x *Record) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Primitives struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *pmongo.Timestamp      `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Regex         *pmongo.Regex          `protobuf:"bytes,2,opt,name=regex,proto3" json:"regex,omitempty"`
+	Code          *pmongo.JavaScript     `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Pointer       *pmongo.DBPointer      `protobuf:"bytes,4,opt,name=pointer,proto3" json:"pointer,omitempty"`
+	Ref           *pmongo.DBRef          `protobuf:"bytes,5,opt,name=ref,proto3" json:"ref,omitempty"`
+	Min           *pmongo.MinKey         `protobuf:"bytes,6,opt,name=min,proto3" json:"min,omitempty"`
+	Max           *pmongo.MaxKey         `protobuf:"bytes,7,opt,name=max,proto3" json:"max,omitempty"`
+	Binary        *pmongo.Binary         `protobuf:"bytes,8,opt,name=binary,proto3" json:"binary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Primitives) Reset() {
+	*x = Primitives{}
+	mi := &file_codecs_test_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Primitives) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Primitives) ProtoMessage() {}
+
+func (x *Primitives) ProtoReflect() protoreflect.Message {
+	mi := &file_codecs_test_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Primitives.ProtoReflect.Descriptor instead.
+func (*Primitives) Descriptor() ([]byte, []int) {
+	return file_codecs_test_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Primitives) GetTimestamp() *pmongo.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Primitives) GetRegex() *pmongo.Regex {
+	if x != nil {
+		return x.Regex
+	}
+	return nil
+}
+
+func (x *Primitives) GetCode() *pmongo.JavaScript {
+	if x != nil {
+		return x.Code
 	}
 	return nil
 }
 
-func (m *Data) GetTimestamp() *timestamp.Timestamp {
-	if m != nil {
-		return m.Timestamp
+func (x *Primitives) GetPointer() *pmongo.DBPointer {
+	if x != nil {
+		return x.Pointer
 	}
 	return nil
 }
 
-func (m *Data) GetId() *pmongo.ObjectId {
-	if m != nil {
-		return m.Id
+func (x *Primitives) GetRef() *pmongo.DBRef {
+	if x != nil {
+		return x.Ref
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Data)(nil), "test.Data")
+func (x *Primitives) GetMin() *pmongo.MinKey {
+	if x != nil {
+		return x.Min
+	}
+	return nil
 }
 
-func init() { proto.RegisterFile("codecs_test.proto", fileDescriptor_b2b3e361c7bc6717) }
+func (x *Primitives) GetMax() *pmongo.MaxKey {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
 
-var fileDescriptor_b2b3e361c7bc6717 = []byte{
-	// 319 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0xd2, 0xcf, 0x4b, 0xc3, 0x30,
-	0x14, 0xc0, 0x71, 0x36, 0xb7, 0xe9, 0xb2, 0x8b, 0x16, 0x84, 0x31, 0x45, 0x87, 0x27, 0x4f, 0x19,
-	0x6c, 0x22, 0x82, 0xe0, 0x41, 0x86, 0xb0, 0x93, 0x10, 0x7f, 0x5c, 0x25, 0x59, 0xb2, 0x12, 0x69,
-	0xfb, 0x4a, 0x93, 0x22, 0xfe, 0x17, 0xfe, 0xc9, 0x92, 0xa4, 0x69, 0x83, 0x23, 0xde, 0x46, 0xf7,
-	0xfd, 0x24, 0x2f, 0xf0, 0xd0, 0xc9, 0x16, 0xb8, 0xd8, 0xaa, 0x0f, 0x2d, 0x94, 0xc6, 0x65, 0x05,
-	0x1a, 0x92, 0x81, 0xf9, 0x3d, 0xbb, 0x4c, 0x01, 0xd2, 0x4c, 0x2c, 0xec, 0x37, 0x56, 0xef, 0x16,
-	0x5a, 0xe6, 0x42, 0x69, 0x9a, 0x97, 0x2e, 0x9b, 0x5d, 0xfc, 0x0d, 0xbe, 0x2a, 0x5a, 0x96, 0xa2,
-	0x52, 0xcd, 0xff, 0xa7, 0x65, 0x0e, 0x45, 0x0a, 0x0b, 0x60, 0x9f, 0x62, 0xab, 0x25, 0x77, 0x9f,
-	0xaf, 0x7e, 0x86, 0x68, 0xb0, 0xa6, 0x9a, 0x26, 0x77, 0x68, 0xcc, 0x00, 0xb2, 0x77, 0x9a, 0xd5,
-	0x62, 0xda, 0x9b, 0xf7, 0xae, 0x27, 0xcb, 0x19, 0x76, 0x67, 0x62, 0x7f, 0x26, 0x7e, 0xf4, 0x05,
-	0xe9, 0xe2, 0xe4, 0x1e, 0x21, 0xf6, 0xad, 0x85, 0x72, 0xb4, 0x6f, 0xe9, 0xd9, 0x3e, 0x6d, 0x13,
-	0x12, 0xe4, 0xc9, 0x03, 0x9a, 0x70, 0xa8, 0x59, 0x26, 0x9c, 0x3e, 0xb0, 0xfa, 0x7c, 0x4f, 0xaf,
-	0xbb, 0x86, 0x84, 0xc0, 0x5c, 0xbe, 0xcb, 0x80, 0x6a, 0xc7, 0x07, 0x91, 0xcb, 0x9f, 0xda, 0x84,
-	0x04, 0xb9, 0xc1, 0xb2, 0xd0, 0xab, 0xa5, 0xc3, 0xc3, 0x08, 0xde, 0xb4, 0x09, 0x09, 0xf2, 0x06,
-	0xdf, 0xde, 0x38, 0x3c, 0x8a, 0xe3, 0x26, 0x21, 0x41, 0x6e, 0x9e, 0xad, 0x74, 0x25, 0x8b, 0xd4,
-	0xe9, 0xc3, 0xc8, 0xb3, 0x5f, 0xba, 0x86, 0x84, 0xc0, 0xf8, 0x3a, 0x18, 0xfd, 0x28, 0xe2, 0xdf,
-	0x82, 0xd9, 0x43, 0xe0, 0xbd, 0x9f, 0x7e, 0xfc, 0x8f, 0xf7, 0xe3, 0x87, 0xc0, 0x6c, 0x4b, 0xbb,
-	0x80, 0x53, 0x14, 0xd9, 0x96, 0x57, 0x5f, 0x90, 0x2e, 0x4e, 0xe6, 0xa8, 0x2f, 0xf9, 0x74, 0x62,
-	0xc9, 0x31, 0x76, 0x4b, 0x89, 0x9f, 0xed, 0x52, 0x6e, 0x38, 0xe9, 0x4b, 0xce, 0x46, 0xf6, 0x80,
-	0xd5, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x39, 0xb1, 0xfa, 0xb9, 0x0c, 0x03, 0x00, 0x00,
+func (x *Primitives) GetBinary() *pmongo.Binary {
+	if x != nil {
+		return x.Binary
+	}
+	return nil
+}
+
+type Place struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *pmongo.Point          `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Zone          *pmongo.Polygon        `protobuf:"bytes,2,opt,name=zone,proto3" json:"zone,omitempty"`
+	Area          *pmongo.Geometry       `protobuf:"bytes,3,opt,name=area,proto3" json:"area,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Place) Reset() {
+	*x = Place{}
+	mi := &file_codecs_test_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Place) ProtoMessage() {}
+
+func (x *Place) ProtoReflect() protoreflect.Message {
+	mi := &file_codecs_test_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Place.ProtoReflect.Descriptor instead.
+func (*Place) Descriptor() ([]byte, []int) {
+	return file_codecs_test_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Place) GetLocation() *pmongo.Point {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Place) GetZone() *pmongo.Polygon {
+	if x != nil {
+		return x.Zone
+	}
+	return nil
+}
+
+func (x *Place) GetArea() *pmongo.Geometry {
+	if x != nil {
+		return x.Area
+	}
+	return nil
+}
+
+var File_codecs_test_proto protoreflect.FileDescriptor
+
+const file_codecs_test_proto_rawDesc = "" +
+	"\n" +
+	"\x11codecs_test.proto\x12\x04test\x1a\x19google/protobuf/any.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x15pmongo/objectid.proto\x1a\x17pmongo/decimal128.proto\x1a\x11pmongo/uuid.proto\x1a\x16pmongo/primitive.proto\x1a\x14pmongo/geojson.proto\"\xd9\r\n" +
+	"\x04Data\x128\n" +
+	"\tboolValue\x18\x01 \x01(\v2\x1a.google.protobuf.BoolValueR\tboolValue\x12;\n" +
+	"\n" +
+	"bytesValue\x18\x02 \x01(\v2\x1b.google.protobuf.BytesValueR\n" +
+	"bytesValue\x12>\n" +
+	"\vdoubleValue\x18\x03 \x01(\v2\x1c.google.protobuf.DoubleValueR\vdoubleValue\x12;\n" +
+	"\n" +
+	"floatValue\x18\x04 \x01(\v2\x1b.google.protobuf.FloatValueR\n" +
+	"floatValue\x12;\n" +
+	"\n" +
+	"int32Value\x18\x05 \x01(\v2\x1b.google.protobuf.Int32ValueR\n" +
+	"int32Value\x12;\n" +
+	"\n" +
+	"int64Value\x18\x06 \x01(\v2\x1b.google.protobuf.Int64ValueR\n" +
+	"int64Value\x12>\n" +
+	"\vstringValue\x18\a \x01(\v2\x1c.google.protobuf.StringValueR\vstringValue\x12>\n" +
+	"\vuint32Value\x18\b \x01(\v2\x1c.google.protobuf.UInt32ValueR\vuint32Value\x12>\n" +
+	"\vuint64Value\x18\t \x01(\v2\x1c.google.protobuf.UInt64ValueR\vuint64Value\x128\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12 \n" +
+	"\x02id\x18\v \x01(\v2\x10.pmongo.ObjectIdR\x02id\x125\n" +
+	"\bduration\x18\f \x01(\v2\x19.google.protobuf.DurationR\bduration\x12/\n" +
+	"\x06struct\x18\r \x01(\v2\x17.google.protobuf.StructR\x06struct\x12,\n" +
+	"\x05value\x18\x0e \x01(\v2\x16.google.protobuf.ValueR\x05value\x12.\n" +
+	"\x04list\x18\x0f \x01(\v2\x1a.google.protobuf.ListValueR\x04list\x12&\n" +
+	"\x03any\x18\x10 \x01(\v2\x14.google.protobuf.AnyR\x03any\x12\x1d\n" +
+	"\n" +
+	"snake_case\x18\x11 \x01(\tR\tsnakeCase\x12&\n" +
+	"\bchildren\x18\x12 \x03(\v2\n" +
+	".test.DataR\bchildren\x12!\n" +
+	"\x05color\x18\x13 \x01(\x0e2\v.test.ColorR\x05color\x12#\n" +
+	"\x06colors\x18\x14 \x03(\x0e2\v.test.ColorR\x06colors\x12\x18\n" +
+	"\acounter\x18\x15 \x01(\x04R\acounter\x123\n" +
+	"\tbinary_id\x18\x16 \x01(\v2\x16.pmongo.BinaryObjectIdR\bbinaryId\x12\x14\n" +
+	"\x04name\x18\x17 \x01(\tH\x00R\x04name\x12\x18\n" +
+	"\x06number\x18\x18 \x01(\x03H\x00R\x06number\x120\n" +
+	"\x04time\x18\x19 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x04time\x12\"\n" +
+	"\x05child\x18\x1a \x01(\v2\n" +
+	".test.DataH\x00R\x05child\x12.\n" +
+	"\x06labels\x18\x1b \x03(\v2\x16.test.Data.LabelsEntryR\x06labels\x12+\n" +
+	"\x05flags\x18\x1c \x03(\v2\x15.test.Data.FlagsEntryR\x05flags\x12*\n" +
+	"\x06amount\x18\x1d \x01(\v2\x12.pmongo.Decimal128R\x06amount\x12 \n" +
+	"\x04uuid\x18\x1e \x01(\v2\f.pmongo.UUIDR\x04uuid\x12.\n" +
+	"\x06values\x18\x1f \x03(\v2\x16.google.protobuf.ValueR\x06values\x12:\n" +
+	"\n" +
+	"attributes\x18  \x03(\v2\x1a.test.Data.AttributesEntryR\n" +
+	"attributes\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aD\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\bR\x03key\x12 \n" +
+	"\x05value\x18\x02 \x01(\v2\n" +
+	".test.DataR\x05value:\x028\x01\x1aU\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value:\x028\x01B\x06\n" +
+	"\x04kind\"?\n" +
+	"\x06Record\x12!\n" +
+	"\x03_id\x18\x01 \x01(\v2\x10.pmongo.ObjectIdR\x02Id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xc4\x02\n" +
+	"\n" +
+	"Primitives\x12/\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x11.pmongo.TimestampR\ttimestamp\x12#\n" +
+	"\x05regex\x18\x02 \x01(\v2\r.pmongo.RegexR\x05regex\x12&\n" +
+	"\x04code\x18\x03 \x01(\v2\x12.pmongo.JavaScriptR\x04code\x12+\n" +
+	"\apointer\x18\x04 \x01(\v2\x11.pmongo.DBPointerR\apointer\x12\x1f\n" +
+	"\x03ref\x18\x05 \x01(\v2\r.pmongo.DBRefR\x03ref\x12 \n" +
+	"\x03min\x18\x06 \x01(\v2\x0e.pmongo.MinKeyR\x03min\x12 \n" +
+	"\x03max\x18\a \x01(\v2\x0e.pmongo.MaxKeyR\x03max\x12&\n" +
+	"\x06binary\x18\b \x01(\v2\x0e.pmongo.BinaryR\x06binary\"}\n" +
+	"\x05Place\x12)\n" +
+	"\blocation\x18\x01 \x01(\v2\r.pmongo.PointR\blocation\x12#\n" +
+	"\x04zone\x18\x02 \x01(\v2\x0f.pmongo.PolygonR\x04zone\x12$\n" +
+	"\x04area\x18\x03 \x01(\v2\x10.pmongo.GeometryR\x04area*2\n" +
+	"\x05Color\x12\x15\n" +
+	"\x11COLOR_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03RED\x10\x01\x12\t\n" +
+	"\x05GREEN\x10\x02B2Z0github.com/amsokol/mongo-go-driver-protobuf/testb\x06proto3"
+
+var (
+	file_codecs_test_proto_rawDescOnce sync.Once
+	file_codecs_test_proto_rawDescData []byte
+)
+
+func file_codecs_test_proto_rawDescGZIP() []byte {
+	file_codecs_test_proto_rawDescOnce.Do(func() {
+		file_codecs_test_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_codecs_test_proto_rawDesc), len(file_codecs_test_proto_rawDesc)))
+	})
+	return file_codecs_test_proto_rawDescData
+}
+
+var file_codecs_test_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_codecs_test_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_codecs_test_proto_goTypes = []any{
+	(Color)(0),                     // 0: test.Color
+	(*Data)(nil),                   // 1: test.Data
+	(*Record)(nil),                 // 2: test.Record
+	(*Primitives)(nil),             // 3: test.Primitives
+	(*Place)(nil),                  // 4: test.Place
+	nil,                            // 5: test.Data.LabelsEntry
+	nil,                            // 6: test.Data.FlagsEntry
+	nil,                            // 7: test.Data.AttributesEntry
+	(*wrapperspb.BoolValue)(nil),   // 8: google.protobuf.BoolValue
+	(*wrapperspb.BytesValue)(nil),  // 9: google.protobuf.BytesValue
+	(*wrapperspb.DoubleValue)(nil), // 10: google.protobuf.DoubleValue
+	(*wrapperspb.FloatValue)(nil),  // 11: google.protobuf.FloatValue
+	(*wrapperspb.Int32Value)(nil),  // 12: google.protobuf.Int32Value
+	(*wrapperspb.Int64Value)(nil),  // 13: google.protobuf.Int64Value
+	(*wrapperspb.StringValue)(nil), // 14: google.protobuf.StringValue
+	(*wrapperspb.UInt32Value)(nil), // 15: google.protobuf.UInt32Value
+	(*wrapperspb.UInt64Value)(nil), // 16: google.protobuf.UInt64Value
+	(*timestamppb.Timestamp)(nil),  // 17: google.protobuf.Timestamp
+	(*pmongo.ObjectId)(nil),        // 18: pmongo.ObjectId
+	(*durationpb.Duration)(nil),    // 19: google.protobuf.Duration
+	(*structpb.Struct)(nil),        // 20: google.protobuf.Struct
+	(*structpb.Value)(nil),         // 21: google.protobuf.Value
+	(*structpb.ListValue)(nil),     // 22: google.protobuf.ListValue
+	(*anypb.Any)(nil),              // 23: google.protobuf.Any
+	(*pmongo.BinaryObjectId)(nil),  // 24: pmongo.BinaryObjectId
+	(*pmongo.Decimal128)(nil),      // 25: pmongo.Decimal128
+	(*pmongo.UUID)(nil),            // 26: pmongo.UUID
+	(*pmongo.Timestamp)(nil),       // 27: pmongo.Timestamp
+	(*pmongo.Regex)(nil),           // 28: pmongo.Regex
+	(*pmongo.JavaScript)(nil),      // 29: pmongo.JavaScript
+	(*pmongo.DBPointer)(nil),       // 30: pmongo.DBPointer
+	(*pmongo.DBRef)(nil),           // 31: pmongo.DBRef
+	(*pmongo.MinKey)(nil),          // 32: pmongo.MinKey
+	(*pmongo.MaxKey)(nil),          // 33: pmongo.MaxKey
+	(*pmongo.Binary)(nil),          // 34: pmongo.Binary
+	(*pmongo.Point)(nil),           // 35: pmongo.Point
+	(*pmongo.Polygon)(nil),         // 36: pmongo.Polygon
+	(*pmongo.Geometry)(nil),        // 37: pmongo.Geometry
+}
+var file_codecs_test_proto_depIdxs = []int32{
+	8,  // 0: test.Data.boolValue:type_name -> google.protobuf.BoolValue
+	9,  // 1: test.Data.bytesValue:type_name -> google.protobuf.BytesValue
+	10, // 2: test.Data.doubleValue:type_name -> google.protobuf.DoubleValue
+	11, // 3: test.Data.floatValue:type_name -> google.protobuf.FloatValue
+	12, // 4: test.Data.int32Value:type_name -> google.protobuf.Int32Value
+	13, // 5: test.Data.int64Value:type_name -> google.protobuf.Int64Value
+	14, // 6: test.Data.stringValue:type_name -> google.protobuf.StringValue
+	15, // 7: test.Data.uint32Value:type_name -> google.protobuf.UInt32Value
+	16, // 8: test.Data.uint64Value:type_name -> google.protobuf.UInt64Value
+	17, // 9: test.Data.timestamp:type_name -> google.protobuf.Timestamp
+	18, // 10: test.Data.id:type_name -> pmongo.ObjectId
+	19, // 11: test.Data.duration:type_name -> google.protobuf.Duration
+	20, // 12: test.Data.struct:type_name -> google.protobuf.Struct
+	21, // 13: test.Data.value:type_name -> google.protobuf.Value
+	22, // 14: test.Data.list:type_name -> google.protobuf.ListValue
+	23, // 15: test.Data.any:type_name -> google.protobuf.Any
+	1,  // 16: test.Data.children:type_name -> test.Data
+	0,  // 17: test.Data.color:type_name -> test.Color
+	0,  // 18: test.Data.colors:type_name -> test.Color
+	24, // 19: test.Data.binary_id:type_name -> pmongo.BinaryObjectId
+	17, // 20: test.Data.time:type_name -> google.protobuf.Timestamp
+	1,  // 21: test.Data.child:type_name -> test.Data
+	5,  // 22: test.Data.labels:type_name -> test.Data.LabelsEntry
+	6,  // 23: test.Data.flags:type_name -> test.Data.FlagsEntry
+	25, // 24: test.Data.amount:type_name -> pmongo.Decimal128
+	26, // 25: test.Data.uuid:type_name -> pmongo.UUID
+	21, // 26: test.Data.values:type_name -> google.protobuf.Value
+	7,  // 27: test.Data.attributes:type_name -> test.Data.AttributesEntry
+	18, // 28: test.Record._id:type_name -> pmongo.ObjectId
+	27, // 29: test.Primitives.timestamp:type_name -> pmongo.Timestamp
+	28, // 30: test.Primitives.regex:type_name -> pmongo.Regex
+	29, // 31: test.Primitives.code:type_name -> pmongo.JavaScript
+	30, // 32: test.Primitives.pointer:type_name -> pmongo.DBPointer
+	31, // 33: test.Primitives.ref:type_name -> pmongo.DBRef
+	32, // 34: test.Primitives.min:type_name -> pmongo.MinKey
+	33, // 35: test.Primitives.max:type_name -> pmongo.MaxKey
+	34, // 36: test.Primitives.binary:type_name -> pmongo.Binary
+	35, // 37: test.Place.location:type_name -> pmongo.Point
+	36, // 38: test.Place.zone:type_name -> pmongo.Polygon
+	37, // 39: test.Place.area:type_name -> pmongo.Geometry
+	1,  // 40: test.Data.FlagsEntry.value:type_name -> test.Data
+	21, // 41: test.Data.AttributesEntry.value:type_name -> google.protobuf.Value
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_codecs_test_proto_init() }
+func file_codecs_test_proto_init() {
+	if File_codecs_test_proto != nil {
+		return
+	}
+	file_codecs_test_proto_msgTypes[0].OneofWrappers = []any{
+		(*Data_Name)(nil),
+		(*Data_Number)(nil),
+		(*Data_Time)(nil),
+		(*Data_Child)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_codecs_test_proto_rawDesc), len(file_codecs_test_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_codecs_test_proto_goTypes,
+		DependencyIndexes: file_codecs_test_proto_depIdxs,
+		EnumInfos:         file_codecs_test_proto_enumTypes,
+		MessageInfos:      file_codecs_test_proto_msgTypes,
+	}.Build()
+	File_codecs_test_proto = out.File
+	file_codecs_test_proto_goTypes = nil
+	file_codecs_test_proto_depIdxs = nil
 }
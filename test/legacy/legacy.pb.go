@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: legacy.proto
+
+package legacy
+
+import (
+	fmt "fmt"
+	pmongo "github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Data is generated by protoc-gen-go of github.com/golang/protobuf before APIv2
+type Data struct {
+	StringValue          *wrappers.StringValue `protobuf:"bytes,1,opt,name=stringValue,proto3" json:"stringValue,omitempty"`
+	Timestamp            *timestamp.Timestamp  `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Id                   *pmongo.ObjectId      `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	SnakeCase            string                `protobuf:"bytes,4,opt,name=snake_case,json=snakeCase,proto3" json:"snake_case,omitempty"`
+	Children             []*Data               `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *Data) Reset()         { *m = Data{} }
+func (m *Data) String() string { return proto.CompactTextString(m) }
+func (*Data) ProtoMessage()    {}
+func (*Data) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4b5c555c498591f0, []int{0}
+}
+
+func (m *Data) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Data.Unmarshal(m, b)
+}
+func (m *Data) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Data.Marshal(b, m, deterministic)
+}
+func (m *Data) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Data.Merge(m, src)
+}
+func (m *Data) XXX_Size() int {
+	return xxx_messageInfo_Data.Size(m)
+}
+func (m *Data) XXX_DiscardUnknown() {
+	xxx_messageInfo_Data.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Data proto.InternalMessageInfo
+
+func (m *Data) GetStringValue() *wrappers.StringValue {
+	if m != nil {
+		return m.StringValue
+	}
+	return nil
+}
+
+func (m *Data) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *Data) GetId() *pmongo.ObjectId {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *Data) GetSnakeCase() string {
+	if m != nil {
+		return m.SnakeCase
+	}
+	return ""
+}
+
+func (m *Data) GetChildren() []*Data {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Data)(nil), "legacy.Data")
+}
+
+func init() { proto.RegisterFile("legacy.proto", fileDescriptor_4b5c555c498591f0) }
+
+var fileDescriptor_4b5c555c498591f0 = []byte{
+	// 281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x3f, 0x4f, 0xc3, 0x30,
+	0x10, 0xc5, 0x95, 0xb4, 0x54, 0xc4, 0xe9, 0x80, 0x2c, 0x21, 0x45, 0x11, 0x7f, 0x22, 0xa6, 0x2c,
+	0xb1, 0xa5, 0x32, 0x00, 0x0b, 0x03, 0xb0, 0x30, 0x21, 0x05, 0xc4, 0xc0, 0x82, 0x9c, 0xf8, 0x70,
+	0x4d, 0x93, 0x38, 0xb2, 0x1d, 0x10, 0x9f, 0x99, 0x2f, 0x81, 0xb0, 0xdb, 0x06, 0xd1, 0xd1, 0xef,
+	0x7e, 0xef, 0xf9, 0xdd, 0xa1, 0x79, 0x03, 0x82, 0xd5, 0x5f, 0xa4, 0xd7, 0xca, 0x2a, 0x3c, 0xf3,
+	0xaf, 0xf4, 0x54, 0x28, 0x25, 0x1a, 0xa0, 0x4e, 0xad, 0x86, 0x37, 0x6a, 0x65, 0x0b, 0xc6, 0xb2,
+	0xb6, 0xf7, 0x60, 0x7a, 0xf2, 0x1f, 0xf8, 0xd4, 0xac, 0xef, 0x41, 0x9b, 0xf5, 0xfc, 0xb0, 0x6f,
+	0x55, 0x27, 0x14, 0x55, 0xd5, 0x3b, 0xd4, 0x56, 0x72, 0x2f, 0x9f, 0x7d, 0x07, 0x68, 0x7a, 0xc7,
+	0x2c, 0xc3, 0xd7, 0x28, 0x36, 0x56, 0xcb, 0x4e, 0x3c, 0xb3, 0x66, 0x80, 0x24, 0xc8, 0x82, 0x3c,
+	0x5e, 0x1c, 0x11, 0x9f, 0x4a, 0x36, 0xa9, 0xe4, 0x71, 0x64, 0xca, 0xbf, 0x06, 0x7c, 0x89, 0xa2,
+	0x6d, 0xa5, 0x24, 0x74, 0xee, 0x74, 0xc7, 0xfd, 0xb4, 0x21, 0xca, 0x11, 0xc6, 0x19, 0x0a, 0x25,
+	0x4f, 0x26, 0xce, 0x72, 0x40, 0x7c, 0x4d, 0xf2, 0xe0, 0x6a, 0xde, 0xf3, 0x32, 0x94, 0x1c, 0x1f,
+	0x23, 0x64, 0x3a, 0xb6, 0x82, 0xd7, 0x9a, 0x19, 0x48, 0xa6, 0x59, 0x90, 0x47, 0x65, 0xe4, 0x94,
+	0x5b, 0x66, 0x00, 0xe7, 0x68, 0xbf, 0x5e, 0xca, 0x86, 0x6b, 0xe8, 0x92, 0xbd, 0x6c, 0x92, 0xc7,
+	0x8b, 0x39, 0x59, 0x1f, 0xf1, 0x77, 0xb5, 0x72, 0x3b, 0xbd, 0xb9, 0x7a, 0xb9, 0x10, 0xd2, 0x2e,
+	0x87, 0x8a, 0xd4, 0xaa, 0xa5, 0xac, 0x35, 0x6a, 0xa5, 0x1a, 0xea, 0x7e, 0x2c, 0x84, 0x2a, 0xb8,
+	0x96, 0x1f, 0xa0, 0x8b, 0xf1, 0xc6, 0x60, 0x2c, 0xf5, 0x49, 0xd5, 0xcc, 0xa9, 0xe7, 0x3f, 0x03,
+	0x00, 0x02, 0x83, 0x87, 0xf7, 0x9f, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,319 @@
+package codecs
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Names of BSON document keys of Timestamp stored as document
+	timestampDateKey  = "date"
+	timestampNanosKey = "nanos"
+)
+
+var (
+	// Protobuf Timestamp types
+	timestampType    = reflect.TypeOf(timestamppb.Timestamp{})
+	timestampPtrType = reflect.PtrTo(timestampType)
+
+	// Time type
+	timeType = reflect.TypeOf(time.Time{})
+
+	// Nanoseconds in second
+	nanosPerSecond = big.NewInt(int64(time.Second))
+)
+
+const (
+	// Valid range of Timestamp seconds: 0001-01-01T00:00:00Z to 9999-12-31T23:59:59Z
+	minTimestampSeconds = -62135596800
+	maxTimestampSeconds = 253402300799
+)
+
+// TimestampRangeError is error of Timestamp out of range from 0001-01-01T00:00:00Z
+// to 9999-12-31T23:59:59.999999999Z
+type TimestampRangeError struct {
+	// Path is dot separated path of message field having Timestamp, e.g. "children.0.timestamp".
+	// It is empty if Timestamp is not encoded as part of Protobuf message.
+	Path string
+	// Seconds and Nanos is value of Timestamp
+	Seconds int64
+	Nanos   int32
+}
+
+// Error returns error message
+func (e *TimestampRangeError) Error() string {
+	msg := fmt.Sprintf("timestamp: (seconds:%d nanos:%d) is out of range [0001-01-01T00:00:00Z, 9999-12-31T23:59:59.999999999Z]",
+		e.Seconds, e.Nanos)
+	if e.Path != "" {
+		msg = fmt.Sprintf("field %s: %s", e.Path, msg)
+	}
+	return msg
+}
+
+// addPathElement adds field name or index to the beginning of path
+func (e *TimestampRangeError) addPathElement(elem string) {
+	e.Path = prependPathElement(e.Path, elem)
+}
+
+// timestampCodec is codec for Protobuf Timestamp.
+// Timestamp is stored as BSON datetime (default), {date, nanos} document or Decimal128 number of seconds.
+// Any of these representations is accepted on decode.
+// Codec is registered for pointer to Timestamp as well to set nil Timestamp for BSON null.
+type timestampCodec struct {
+	precision   TimestampPrecision
+	storage     TimestampStorage
+	rangePolicy TimestampRangePolicy
+}
+
+// EncodeValue encodes Protobuf Timestamp value to BSON value
+func (e *timestampCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	v := messageOf(val).(*timestamppb.Timestamp)
+	if e.precision == TimestampRound && e.storage == TimestampDateTime {
+		// Timestamp is rounded before range check not to write datetime it can't be decoded from
+		v = roundTimestamp(v)
+	}
+	v, err := e.checkRange(v)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return vw.WriteNull()
+	}
+	switch e.storage {
+	case TimestampDocument:
+		return encodeTimestampDocument(vw, v)
+	case TimestampDecimal128:
+		d, err := timestampToDecimal128(v)
+		if err != nil {
+			return err
+		}
+		return vw.WriteDecimal128(d)
+	}
+	t := v.AsTime()
+	enc, err := ectx.LookupEncoder(timeType)
+	if err != nil {
+		return err
+	}
+	return enc.EncodeValue(ectx, vw, reflect.ValueOf(t))
+}
+
+// DecodeValue decodes BSON value to Timestamp value or pointer to Timestamp
+func (e *timestampCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "timestampCodec.DecodeValue", Types: []reflect.Type{timestampType, timestampPtrType}, Received: val}
+	}
+	if isNullValue(vr.Type()) {
+		setNullValue(val)
+		return readNullValue(vr)
+	}
+	ts, err := e.decodeTimestamp(ectx, vr)
+	if err != nil {
+		return err
+	}
+	if ts, err = e.checkRange(ts); err != nil {
+		return err
+	}
+	switch {
+	case val.Kind() != reflect.Ptr:
+		if ts == nil {
+			// Timestamp struct can't be nil, so it is reset
+			ts = &timestamppb.Timestamp{}
+		}
+		setMessage(val, ts)
+	case ts == nil:
+		val.Set(reflect.Zero(val.Type()))
+	default:
+		if val.IsNil() {
+			val.Set(reflect.New(timestampType))
+		}
+		setMessage(val.Elem(), ts)
+	}
+	return nil
+}
+
+// decodeTimestamp reads Timestamp from any of supported BSON representations
+func (e *timestampCodec) decodeTimestamp(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader) (*timestamppb.Timestamp, error) {
+	var ts *timestamppb.Timestamp
+	switch vr.Type() {
+	case bsontype.EmbeddedDocument:
+		var err error
+		if ts, err = decodeTimestampDocument(ectx, vr); err != nil {
+			return nil, err
+		}
+	case bsontype.Decimal128:
+		d, err := vr.ReadDecimal128()
+		if err != nil {
+			return nil, err
+		}
+		if ts, err = timestampFromDecimal128(d); err != nil {
+			return nil, err
+		}
+	default:
+		enc, err := ectx.LookupDecoder(timeType)
+		if err != nil {
+			return nil, err
+		}
+		var t time.Time
+		if err = enc.DecodeValue(ectx, vr, reflect.ValueOf(&t).Elem()); err != nil {
+			return nil, err
+		}
+		ts = timestamppb.New(t)
+	}
+	return ts, nil
+}
+
+// checkRange applies range policy to Timestamp.
+// It returns nil Timestamp if Timestamp must be stored as null.
+func (e *timestampCodec) checkRange(ts *timestamppb.Timestamp) (*timestamppb.Timestamp, error) {
+	if ts.GetNanos() < 0 || ts.GetNanos() >= int32(time.Second) {
+		// Invalid nanos are not range issue and can't be fixed by policy
+		return nil, ts.CheckValid()
+	}
+	if ts.GetSeconds() >= minTimestampSeconds && ts.GetSeconds() <= maxTimestampSeconds {
+		return ts, nil
+	}
+	switch e.rangePolicy {
+	case TimestampRangeClamp:
+		if ts.GetSeconds() < minTimestampSeconds {
+			return &timestamppb.Timestamp{Seconds: minTimestampSeconds}, nil
+		}
+		return &timestamppb.Timestamp{Seconds: maxTimestampSeconds, Nanos: int32(time.Second - 1)}, nil
+	case TimestampRangeNull:
+		return nil, nil
+	default:
+		return nil, &TimestampRangeError{Seconds: ts.GetSeconds(), Nanos: ts.GetNanos()}
+	}
+}
+
+// roundTimestamp rounds Timestamp to the nearest millisecond the same way as time.Time.Round does.
+// Timestamp having invalid nanos is returned as is.
+func roundTimestamp(ts *timestamppb.Timestamp) *timestamppb.Timestamp {
+	nanos := ts.GetNanos()
+	if nanos < 0 || nanos >= int32(time.Second) {
+		return ts
+	}
+	nanos = (nanos + int32(time.Millisecond/2)) / int32(time.Millisecond) * int32(time.Millisecond)
+	if nanos == int32(time.Second) {
+		return &timestamppb.Timestamp{Seconds: ts.GetSeconds() + 1}
+	}
+	return &timestamppb.Timestamp{Seconds: ts.GetSeconds(), Nanos: nanos}
+}
+
+// encodeTimestampDocument writes Timestamp as {date, nanos} BSON document
+func encodeTimestampDocument(vw bsonrw.ValueWriter, ts *timestamppb.Timestamp) error {
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	dvw, err := dw.WriteDocumentElement(timestampDateKey)
+	if err != nil {
+		return err
+	}
+	// Nanos are never negative so division truncates datetime to milliseconds
+	if err = dvw.WriteDateTime(ts.GetSeconds()*1000 + int64(ts.GetNanos())/int64(time.Millisecond)); err != nil {
+		return err
+	}
+	nvw, err := dw.WriteDocumentElement(timestampNanosKey)
+	if err != nil {
+		return err
+	}
+	if err = nvw.WriteInt32(ts.GetNanos()); err != nil {
+		return err
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// decodeTimestampDocument reads Timestamp from {date, nanos} BSON document.
+// Nanoseconds of second are taken from datetime milliseconds if "nanos" key is absent.
+func decodeTimestampDocument(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader) (*timestamppb.Timestamp, error) {
+	dr, err := vr.ReadDocument()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		date, hasDate   = int64(0), false
+		nanos, hasNanos = int32(0), false
+	)
+	for {
+		key, evr, err := dr.ReadElement()
+		if err == bsonrw.ErrEOD {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case timestampDateKey:
+			if date, err = evr.ReadDateTime(); err != nil {
+				return nil, err
+			}
+			hasDate = true
+		case timestampNanosKey:
+			dec, err := ectx.LookupDecoder(int32Type)
+			if err != nil {
+				return nil, err
+			}
+			if err = dec.DecodeValue(ectx, evr, reflect.ValueOf(&nanos).Elem()); err != nil {
+				return nil, err
+			}
+			hasNanos = true
+		default:
+			return nil, fmt.Errorf("timestamp: unexpected key %q in document", key)
+		}
+	}
+	if !hasDate {
+		return nil, fmt.Errorf("timestamp: document has no %q key", timestampDateKey)
+	}
+	seconds, ms := date/1000, date%1000
+	if ms < 0 {
+		seconds, ms = seconds-1, ms+1000
+	}
+	if !hasNanos {
+		nanos = int32(ms) * int32(time.Millisecond)
+	}
+	return &timestamppb.Timestamp{Seconds: seconds, Nanos: nanos}, nil
+}
+
+// timestampToDecimal128 converts Timestamp to Decimal128 number of seconds having nine decimal places
+func timestampToDecimal128(ts *timestamppb.Timestamp) (primitive.Decimal128, error) {
+	n := new(big.Int).Mul(big.NewInt(ts.GetSeconds()), nanosPerSecond)
+	n.Add(n, big.NewInt(int64(ts.GetNanos())))
+	return primitive.ParseDecimal128(new(big.Rat).SetFrac(n, nanosPerSecond).FloatString(9))
+}
+
+// timestampFromDecimal128 converts Decimal128 number of seconds to Timestamp.
+// Number must not have precision finer than nanoseconds.
+// Seconds out of int64 range are saturated to be handled by range policy.
+func timestampFromDecimal128(d primitive.Decimal128) (*timestamppb.Timestamp, error) {
+	r, ok := new(big.Rat).SetString(d.String())
+	if !ok {
+		return nil, fmt.Errorf("timestamp: cannot decode Decimal128 %v", d)
+	}
+	r.Mul(r, new(big.Rat).SetInt(nanosPerSecond))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("timestamp: Decimal128 %v has precision finer than nanoseconds", d)
+	}
+	seconds, nanos := new(big.Int).DivMod(r.Num(), nanosPerSecond, new(big.Int))
+	if !seconds.IsInt64() {
+		if seconds.Sign() < 0 {
+			return &timestamppb.Timestamp{Seconds: math.MinInt64}, nil
+		}
+		return &timestamppb.Timestamp{Seconds: math.MaxInt64}, nil
+	}
+	return &timestamppb.Timestamp{Seconds: seconds.Int64(), Nanos: int32(nanos.Int64())}, nil
+}
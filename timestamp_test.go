@@ -0,0 +1,250 @@
+package codecs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestTimestampStorage(t *testing.T) {
+	ts := timestamppb.New(time.Date(2019, 2, 1, 10, 20, 30, 123456789, time.UTC))
+	decimal, err := primitive.ParseDecimal128("1549016430.123456789")
+	if err != nil {
+		t.Errorf("primitive.ParseDecimal128 error = %v", err)
+		return
+	}
+
+	tests := []struct {
+		name    string
+		storage TimestampStorage
+		in      *timestamppb.Timestamp
+		expect  interface{}
+	}{
+		{
+			name:    "document",
+			storage: TimestampDocument,
+			in:      ts,
+			expect: bson.D{
+				{Key: "date", Value: primitive.DateTime(1549016430123)},
+				{Key: "nanos", Value: int32(123456789)},
+			},
+		},
+		{
+			name:    "document before epoch",
+			storage: TimestampDocument,
+			in:      &timestamppb.Timestamp{Seconds: -2, Nanos: 500000001},
+			expect: bson.D{
+				{Key: "date", Value: primitive.DateTime(-1500)},
+				{Key: "nanos", Value: int32(500000001)},
+			},
+		},
+		{
+			name:    "decimal128",
+			storage: TimestampDecimal128,
+			in:      ts,
+			expect:  decimal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTimestampStorage(tt.storage))).Build()
+
+			b, err := bson.MarshalWithRegistry(r, &test.Data{Timestamp: tt.in})
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+
+			var doc struct {
+				Timestamp interface{} `bson:"timestamp"`
+			}
+			if err = bson.Unmarshal(b, &doc); err != nil {
+				t.Errorf("bson.Unmarshal error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.expect, doc.Timestamp) {
+				t.Errorf("failed: expected=%#v, value=%#v", tt.expect, doc.Timestamp)
+				return
+			}
+
+			var out test.Data
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(tt.in, out.Timestamp) {
+				t.Errorf("failed: in=%v, out=%v", tt.in, out.Timestamp)
+				return
+			}
+		})
+	}
+
+	t.Run("any representation is decoded", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		for _, v := range []interface{}{
+			primitive.DateTime(1549016430123),
+			bson.D{{Key: "date", Value: primitive.DateTime(1549016430123)}},
+			decimal,
+		} {
+			b, err := bson.Marshal(bson.D{{Key: "timestamp", Value: v}})
+			if err != nil {
+				t.Errorf("bson.Marshal error = %v", err)
+				return
+			}
+			var out test.Data
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if out.Timestamp.GetSeconds() != ts.GetSeconds() || out.Timestamp.GetNanos()/int32(time.Millisecond) != 123 {
+				t.Errorf("failed: value=%#v, out=%v", v, out.Timestamp)
+				return
+			}
+		}
+	})
+
+	t.Run("decimal128 finer than nanoseconds", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		d, err := primitive.ParseDecimal128("1.0000000001")
+		if err != nil {
+			t.Errorf("primitive.ParseDecimal128 error = %v", err)
+			return
+		}
+		b, err := bson.Marshal(bson.D{{Key: "timestamp", Value: d}})
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err == nil {
+			t.Errorf("bson.UnmarshalWithRegistry error expected for %v", d)
+			return
+		}
+	})
+}
+
+func TestTimestampRangePolicy(t *testing.T) {
+	// Sentinel date one millisecond before 0001-01-01T00:00:00Z
+	sentinel, err := bson.Marshal(bson.D{{Key: "timestamp", Value: primitive.DateTime(-62135596800001)}})
+	if err != nil {
+		t.Errorf("bson.Marshal error = %v", err)
+		return
+	}
+	tooLate := &test.Data{Children: []*test.Data{{Timestamp: &timestamppb.Timestamp{Seconds: 253402300800}}}}
+
+	t.Run("fail", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		_, err := bson.MarshalWithRegistry(r, tooLate)
+		var rerr *TimestampRangeError
+		if !errors.As(err, &rerr) || rerr.Path != "children.0.timestamp" || rerr.Seconds != 253402300800 {
+			t.Errorf("failed: error=%v", err)
+			return
+		}
+
+		var out test.Data
+		err = bson.UnmarshalWithRegistry(r, sentinel, &out)
+		if !errors.As(err, &rerr) || rerr.Path != "timestamp" {
+			t.Errorf("failed: error=%v", err)
+			return
+		}
+	})
+
+	t.Run("clamp", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTimestampRangePolicy(TimestampRangeClamp))).Build()
+
+		b, err := bson.MarshalWithRegistry(r, tooLate)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		v, err := bson.Raw(b).LookupErr("children", "0", "timestamp")
+		if err != nil {
+			t.Errorf("bson.Raw.LookupErr error = %v", err)
+			return
+		}
+		if dt, ok := v.DateTimeOK(); !ok || dt != 253402300799999 {
+			t.Errorf("failed: timestamp=%v", v)
+			return
+		}
+
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, sentinel, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if !proto.Equal(&timestamppb.Timestamp{Seconds: -62135596800}, out.Timestamp) {
+			t.Errorf("failed: out=%v", out.Timestamp)
+			return
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTimestampRangePolicy(TimestampRangeNull))).Build()
+
+		b, err := bson.MarshalWithRegistry(r, tooLate)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		v, err := bson.Raw(b).LookupErr("children", "0", "timestamp")
+		if err != nil {
+			t.Errorf("bson.Raw.LookupErr error = %v", err)
+			return
+		}
+		if v.Type != bsontype.Null {
+			t.Errorf("failed: timestamp=%v", v)
+			return
+		}
+
+		out := test.Data{Timestamp: timestamppb.Now()}
+		if err = bson.UnmarshalWithRegistry(r, sentinel, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if out.Timestamp != nil {
+			t.Errorf("failed: out=%v", out.Timestamp)
+			return
+		}
+	})
+
+	t.Run("rounding at range boundary", func(t *testing.T) {
+		last := &test.Data{Timestamp: &timestamppb.Timestamp{Seconds: 253402300799, Nanos: 999999999}}
+
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithTimestampPrecision(TimestampRound))).Build()
+		_, err := bson.MarshalWithRegistry(r, last)
+		var rerr *TimestampRangeError
+		if !errors.As(err, &rerr) || rerr.Path != "timestamp" || rerr.Seconds != 253402300800 {
+			t.Errorf("failed: error=%v", err)
+			return
+		}
+
+		r = RegisterWithOptions(bson.NewRegistryBuilder(),
+			NewOptions(WithTimestampPrecision(TimestampRound), WithTimestampRangePolicy(TimestampRangeClamp))).Build()
+		b, err := bson.MarshalWithRegistry(r, last)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if !proto.Equal(&timestamppb.Timestamp{Seconds: 253402300799, Nanos: 999000000}, out.Timestamp) {
+			t.Errorf("failed: out=%v", out.Timestamp)
+			return
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package codecs
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// UnknownFieldsKey is reserved BSON document key keeping unknown proto fields
+	// in wire format with UnknownFieldsKeep policy
+	UnknownFieldsKey = "_unknown"
+
+	// unknownKeysFieldNumber is number of reserved proto field keeping BSON elements unknown to message
+	// in unknown field set of message with UnknownFieldsKeep policy.
+	// It is the maximal valid field number, which is unlikely to be declared. Messages declaring it
+	// fail to decode documents having unknown keys with UnknownFieldsKeep policy.
+	unknownKeysFieldNumber = protowire.MaxValidNumber
+)
+
+// encodeUnknown writes unknown fields of message to BSON document:
+// BSON elements unknown to message on decode are written back as they were,
+// other unknown proto fields are written as BSON binary in wire format under UnknownFieldsKey.
+func encodeUnknown(dw bsonrw.DocumentWriter, m protoreflect.Message, md *messageDescription) error {
+	var fields []byte
+	for b := m.GetUnknown(); len(b) > 0; {
+		num, typ, n := protowire.ConsumeField(b)
+		if n < 0 {
+			return fmt.Errorf("message %s: invalid unknown fields: %v", m.Descriptor().FullName(), protowire.ParseError(n))
+		}
+		field := b[:n]
+		b = b[n:]
+		if num != unknownKeysFieldNumber || typ != protowire.BytesType {
+			fields = append(fields, field...)
+			continue
+		}
+		_, _, tn := protowire.ConsumeTag(field)
+		doc, _ := protowire.ConsumeBytes(field[tn:])
+		elems, err := bsoncore.Document(doc).Elements()
+		if err != nil {
+			return err
+		}
+		for _, elem := range elems {
+			if _, ok := md.fm[elem.Key()]; ok || elem.Key() == UnknownFieldsKey {
+				// Key became known to message or is reserved
+				continue
+			}
+			evw, err := dw.WriteDocumentElement(elem.Key())
+			if err != nil {
+				return err
+			}
+			ev := elem.Value()
+			if err = bsonrw.NewCopier().CopyValueFromBytes(evw, ev.Type, ev.Data); err != nil {
+				return err
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	fvw, err := dw.WriteDocumentElement(UnknownFieldsKey)
+	if err != nil {
+		return err
+	}
+	return fvw.WriteBinary(fields)
+}
+
+// unknownDecoder collects BSON elements unknown to message and unknown proto fields
+// stored under UnknownFieldsKey to restore them into unknown field set of message
+type unknownDecoder struct {
+	elems  [][]byte
+	fields []byte
+}
+
+// decodeElement reads unknown BSON element
+func (d *unknownDecoder) decodeElement(key string, vr bsonrw.ValueReader) error {
+	if key == UnknownFieldsKey {
+		if vr.Type() != bsontype.Binary {
+			return fmt.Errorf("key %q must be binary, but got %v", UnknownFieldsKey, vr.Type())
+		}
+		b, _, err := vr.ReadBinary()
+		if err != nil {
+			return err
+		}
+		d.fields = append(d.fields, b...)
+		return nil
+	}
+	t, b, err := bsonrw.NewCopier().CopyValueToBytes(vr)
+	if err != nil {
+		return err
+	}
+	d.elems = append(d.elems, append(bsoncore.AppendHeader(nil, t, key), b...))
+	return nil
+}
+
+// setUnknown adds collected unknown fields to unknown field set of message.
+// BSON elements kept by previous decode into the same message are replaced by collected ones having the same keys,
+// so they are never written twice by encodeUnknown.
+func (d *unknownDecoder) setUnknown(m protoreflect.Message) error {
+	if len(d.fields) == 0 && len(d.elems) == 0 {
+		return nil
+	}
+	if len(d.elems) == 0 {
+		m.SetUnknown(append(append([]byte(nil), m.GetUnknown()...), d.fields...))
+		return nil
+	}
+	if err := checkUnknownKeysField(m.Descriptor()); err != nil {
+		return err
+	}
+	keys := make(map[string]bool, len(d.elems))
+	for _, elem := range d.elems {
+		keys[bsoncore.Element(elem).Key()] = true
+	}
+	var b []byte
+	var elems [][]byte
+	for u := m.GetUnknown(); len(u) > 0; {
+		num, typ, n := protowire.ConsumeField(u)
+		if n < 0 {
+			return fmt.Errorf("message %s: invalid unknown fields: %v", m.Descriptor().FullName(), protowire.ParseError(n))
+		}
+		field := u[:n]
+		u = u[n:]
+		if num != unknownKeysFieldNumber || typ != protowire.BytesType {
+			b = append(b, field...)
+			continue
+		}
+		_, _, tn := protowire.ConsumeTag(field)
+		doc, _ := protowire.ConsumeBytes(field[tn:])
+		kept, err := bsoncore.Document(doc).Elements()
+		if err != nil {
+			return err
+		}
+		for _, elem := range kept {
+			if !keys[elem.Key()] {
+				elems = append(elems, elem)
+			}
+		}
+	}
+	b = append(b, d.fields...)
+	b = protowire.AppendTag(b, unknownKeysFieldNumber, protowire.BytesType)
+	b = protowire.AppendBytes(b, bsoncore.BuildDocumentFromElements(nil, append(elems, d.elems...)...))
+	m.SetUnknown(b)
+	return nil
+}
+
+// checkUnknownKeysField returns error if message declares field having reserved unknownKeysFieldNumber,
+// so unknown BSON elements can't be kept in its unknown field set
+func checkUnknownKeysField(md protoreflect.MessageDescriptor) error {
+	if fd := md.Fields().ByNumber(unknownKeysFieldNumber); fd != nil {
+		return fmt.Errorf("message %s: field %s has number %d reserved to keep unknown fields, use UnknownFieldsDrop policy",
+			md.FullName(), fd.Name(), unknownKeysFieldNumber)
+	}
+	return nil
+}
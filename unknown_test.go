@@ -0,0 +1,186 @@
+package codecs
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestUnknownFieldsPolicy(t *testing.T) {
+	// Field 100 of newer version of message
+	var unknown []byte
+	unknown = protowire.AppendTag(unknown, 100, protowire.VarintType)
+	unknown = protowire.AppendVarint(unknown, 42)
+
+	in := &test.Data{SnakeCase: "qwerty", Children: []*test.Data{{SnakeCase: "child"}}}
+	in.ProtoReflect().SetUnknown(unknown)
+	in.Children[0].ProtoReflect().SetUnknown(unknown)
+
+	t.Run("keep unknown proto fields", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUnknownFieldsPolicy(UnknownFieldsKeep))).Build()
+
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if v, err := bson.Raw(b).LookupErr(UnknownFieldsKey); err != nil {
+			t.Errorf("failed: key %q is not found, error=%v", UnknownFieldsKey, err)
+			return
+		} else if _, data, ok := v.BinaryOK(); !ok || !reflect.DeepEqual(unknown, data) {
+			t.Errorf("failed: %s=%v", UnknownFieldsKey, v)
+			return
+		}
+
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if !proto.Equal(in, &out) {
+			t.Errorf("failed: in=%v, out=%v", in, &out)
+			return
+		}
+	})
+
+	t.Run("keep unknown keys", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUnknownFieldsPolicy(UnknownFieldsKeep))).Build()
+
+		doc := bson.D{
+			{Key: "snake_case", Value: "qwerty"},
+			{Key: "added", Value: bson.D{{Key: "nested", Value: int32(1)}}},
+			{Key: "children", Value: bson.A{bson.D{{Key: "added", Value: "child"}}}},
+		}
+		b, err := bson.Marshal(doc)
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		out.SnakeCase = "changed"
+
+		if b, err = bson.MarshalWithRegistry(r, &out); err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		var result bson.D
+		if err = bson.Unmarshal(b, &result); err != nil {
+			t.Errorf("bson.Unmarshal error = %v", err)
+			return
+		}
+		expect := bson.D{
+			{Key: "snake_case", Value: "changed"},
+			{Key: "children", Value: bson.A{bson.D{{Key: "added", Value: "child"}}}},
+			{Key: "added", Value: bson.D{{Key: "nested", Value: int32(1)}}},
+		}
+		if !reflect.DeepEqual(expect, result) {
+			t.Errorf("failed: expected=%v, result=%v", expect, result)
+			return
+		}
+	})
+
+	t.Run("decode twice", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUnknownFieldsPolicy(UnknownFieldsKeep))).Build()
+
+		var out test.Data
+		for _, doc := range []bson.D{
+			{{Key: "newField", Value: "x"}, {Key: "kept", Value: int32(1)}},
+			{{Key: "newField", Value: "y"}},
+		} {
+			b, err := bson.Marshal(doc)
+			if err != nil {
+				t.Errorf("bson.Marshal error = %v", err)
+				return
+			}
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+		}
+
+		b, err := bson.MarshalWithRegistry(r, &out)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		var result bson.D
+		if err = bson.Unmarshal(b, &result); err != nil {
+			t.Errorf("bson.Unmarshal error = %v", err)
+			return
+		}
+		expect := bson.D{{Key: "kept", Value: int32(1)}, {Key: "newField", Value: "y"}}
+		if !reflect.DeepEqual(expect, result) {
+			t.Errorf("failed: expected=%v, result=%v", expect, result)
+			return
+		}
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		r := Register(bson.NewRegistryBuilder()).Build()
+
+		b, err := bson.MarshalWithRegistry(r, in)
+		if err != nil {
+			t.Errorf("bson.MarshalWithRegistry error = %v", err)
+			return
+		}
+		if _, err = bson.Raw(b).LookupErr(UnknownFieldsKey); err == nil {
+			t.Errorf("failed: key %q is not expected", UnknownFieldsKey)
+			return
+		}
+
+		var out test.Data
+		if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+			t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+			return
+		}
+		if len(out.ProtoReflect().GetUnknown()) != 0 {
+			t.Errorf("failed: unknown fields are not dropped, out=%v", &out)
+			return
+		}
+	})
+	t.Run("reserved field number declared", func(t *testing.T) {
+		r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUnknownFieldsPolicy(UnknownFieldsKeep))).Build()
+
+		fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+			Name:    proto.String("reserved.proto"),
+			Package: proto.String("test"),
+			Syntax:  proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("Reserved"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:     proto.String("last"),
+					JsonName: proto.String("last"),
+					Number:   proto.Int32(int32(protowire.MaxValidNumber)),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				}},
+			}},
+		}, nil)
+		if err != nil {
+			t.Errorf("protodesc.NewFile error = %v", err)
+			return
+		}
+		b, err := bson.Marshal(bson.D{{Key: "last", Value: "qwerty"}, {Key: "added", Value: int32(1)}})
+		if err != nil {
+			t.Errorf("bson.Marshal error = %v", err)
+			return
+		}
+		out := dynamicpb.NewMessage(fd.Messages().Get(0))
+		if err = bson.UnmarshalWithRegistry(r, b, out); err == nil {
+			t.Errorf("bson.UnmarshalWithRegistry error expected for message declaring field %d", protowire.MaxValidNumber)
+			return
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package codecs
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+)
+
+const (
+	// BSON binary subtypes of UUID
+	uuidSubtype       byte = 0x04
+	uuidLegacySubtype byte = 0x03
+)
+
+var (
+	// UUID type
+	uuidType = reflect.TypeOf(pmongo.UUID{})
+)
+
+// uuidCodec is codec for Protobuf UUID
+type uuidCodec struct {
+	representation UUIDRepresentation
+}
+
+// EncodeValue encodes Protobuf UUID value to BSON binary value
+func (e *uuidCodec) EncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	v := messageOf(val).(*pmongo.UUID)
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	if e.representation == UUIDStandard {
+		return vw.WriteBinaryWithSubtype(v.Value, uuidSubtype)
+	}
+	return vw.WriteBinaryWithSubtype(legacyUUIDBytes(v.Value, e.representation), uuidLegacySubtype)
+}
+
+// DecodeValue decodes BSON binary value to UUID value.
+// Binary subtype 4 is read as standard UUID, subtype 3 is read with byte order of legacy representation.
+func (e *uuidCodec) DecodeValue(ectx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "uuidCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Ptr, reflect.Struct}, Received: val}
+	}
+	if isNullValue(vr.Type()) {
+		setNullValue(val)
+		return readNullValue(vr)
+	}
+	if vr.Type() != bsontype.Binary {
+		return fmt.Errorf("cannot decode %v into UUID", vr.Type())
+	}
+	b, subtype, err := vr.ReadBinary()
+	if err != nil {
+		return err
+	}
+	switch subtype {
+	case uuidSubtype:
+	case uuidLegacySubtype:
+		b = legacyUUIDBytes(b, e.representation)
+	default:
+		return fmt.Errorf("cannot decode binary subtype %d into UUID, subtype 4 or 3 expected", subtype)
+	}
+	v, err := pmongo.NewUUIDFromBytes(b)
+	if err != nil {
+		return err
+	}
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	setMessage(val, v)
+	return nil
+}
+
+// legacyUUIDBytes converts UUID bytes between network byte order and byte order of legacy representation.
+// Conversion is symmetric, so it is used for both encode and decode.
+func legacyUUIDBytes(b []byte, representation UUIDRepresentation) []byte {
+	b = append([]byte(nil), b...)
+	if len(b) != 16 {
+		return b
+	}
+	switch representation {
+	case UUIDJavaLegacy:
+		reverseBytes(b[0:8])
+		reverseBytes(b[8:16])
+	case UUIDCSharpLegacy:
+		reverseBytes(b[0:4])
+		reverseBytes(b[4:6])
+		reverseBytes(b[6:8])
+	}
+	return b
+}
+
+// reverseBytes reverses byte order of b in place
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
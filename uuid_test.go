@@ -0,0 +1,111 @@
+package codecs
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/amsokol/mongo-go-driver-protobuf/pmongo"
+	"github.com/amsokol/mongo-go-driver-protobuf/test"
+)
+
+func TestUUIDCodec(t *testing.T) {
+	u, _ := pmongo.ParseUUID("00112233-4455-6677-8899-aabbccddeeff")
+
+	tests := []struct {
+		name           string
+		representation UUIDRepresentation
+		expect         primitive.Binary
+	}{
+		{
+			name:           "standard",
+			representation: UUIDStandard,
+			expect:         primitive.Binary{Subtype: 4, Data: []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}},
+		},
+		{
+			name:           "python legacy",
+			representation: UUIDPythonLegacy,
+			expect:         primitive.Binary{Subtype: 3, Data: []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}},
+		},
+		{
+			name:           "java legacy",
+			representation: UUIDJavaLegacy,
+			expect:         primitive.Binary{Subtype: 3, Data: []byte{0x77, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11, 0x00, 0xff, 0xee, 0xdd, 0xcc, 0xbb, 0xaa, 0x99, 0x88}},
+		},
+		{
+			name:           "c# legacy",
+			representation: UUIDCSharpLegacy,
+			expect:         primitive.Binary{Subtype: 3, Data: []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RegisterWithOptions(bson.NewRegistryBuilder(), NewOptions(WithUUIDRepresentation(tt.representation))).Build()
+
+			in := &test.Data{Uuid: u}
+			b, err := bson.MarshalWithRegistry(r, in)
+			if err != nil {
+				t.Errorf("bson.MarshalWithRegistry error = %v", err)
+				return
+			}
+			subtype, data, ok := bson.Raw(b).Lookup("uuid").BinaryOK()
+			if !ok || subtype != tt.expect.Subtype || string(data) != string(tt.expect.Data) {
+				t.Errorf("failed: uuid=%v, expected=%v", bson.Raw(b).Lookup("uuid"), tt.expect)
+				return
+			}
+
+			var out test.Data
+			if err = bson.UnmarshalWithRegistry(r, b, &out); err != nil {
+				t.Errorf("bson.UnmarshalWithRegistry error = %v", err)
+				return
+			}
+			if !proto.Equal(in, &out) {
+				t.Errorf("failed: in=%v, out=%v", in, &out)
+				return
+			}
+
+			// Standard UUID is read regardless of representation
+			out.Reset()
+			if err = decodeDocument(r, bson.D{{Key: "uuid", Value: tests[0].expect}}, &out); err != nil {
+				t.Errorf("decodeDocument error = %v", err)
+				return
+			}
+			if !proto.Equal(in, &out) {
+				t.Errorf("failed: in=%v, out=%v", in, &out)
+				return
+			}
+		})
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		runFailCases(t, Register(bson.NewRegistryBuilder()).Build(), []failCase{
+			{
+				name:   "short value",
+				in:     &test.Data{Uuid: &pmongo.UUID{Value: []byte{1}}},
+				target: new(*pmongo.InvalidUUIDError),
+				reason: `invalid UUID "01"`,
+			},
+			{
+				name:   "short binary",
+				doc:    bson.D{{Key: "uuid", Value: primitive.Binary{Subtype: 4, Data: make([]byte, 15)}}},
+				out:    &test.Data{},
+				target: new(*pmongo.InvalidUUIDError),
+				reason: "invalid UUID",
+			},
+			{
+				name:   "binary subtype 0",
+				doc:    bson.D{{Key: "uuid", Value: primitive.Binary{Data: make([]byte, 16)}}},
+				out:    &test.Data{},
+				reason: "cannot decode binary subtype 0 into UUID",
+			},
+			{
+				name:   "string",
+				doc:    bson.D{{Key: "uuid", Value: "00112233-4455-6677-8899-aabbccddeeff"}},
+				out:    &test.Data{},
+				reason: "cannot decode string into UUID",
+			},
+		})
+	})
+}